@@ -0,0 +1,301 @@
+package notionapi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// maxAppendChildren is the maximum length of a children array
+	maxAppendChildren = 100
+	// maxAppendDepth is the number of levels of blocks in one request
+	maxAppendDepth = 2
+	// maxAppendBlocks is the maximum number of blocks in one request
+	maxAppendBlocks = 1000
+	// maxTextLength is the maximum number of characters of a text content
+	maxTextLength = 2000
+)
+
+// AppendAllOptions configures BlockService.AppendAll
+type AppendAllOptions struct {
+	// Progress is called after every request with the number of blocks
+	// appended so far and the total number of blocks
+	Progress func(appended, total int)
+}
+
+// AppendAll appends children of any number and depth to the block id,
+// working around the limits of AppendChildren:
+//
+// - children are sent in batches of 100
+// - children nested deeper than two levels are appended by follow-up
+// requests to their parent, whose ID is looked up in its new siblings
+// - text content longer than 2000 characters is split into several rich
+// text objects with the same annotations
+//
+// The requests are sent one after another, so the blocks keep their order.
+// children are not modified. PageService.Create uses AppendAll for children
+// which don't fit into one request.
+func (bc *BlockClient) AppendAll(ctx context.Context, id BlockID, children []Block, opts *AppendAllOptions) error {
+	nodes := prepareAppend(children)
+	a := &appender{blocks: bc, total: countNodes(nodes)}
+	if opts != nil {
+		a.progress = opts.Progress
+	}
+	return a.append(ctx, id, nodes)
+}
+
+// appendNode is a block to append without its children, which are kept in
+// the tree. sent is the number of children sent in the last request.
+type appendNode struct {
+	block    Block
+	children []*appendNode
+	sent     int
+}
+
+// prepareAppend copies blocks into a tree of nodes and splits their long
+// texts
+func prepareAppend(blocks []Block) []*appendNode {
+	nodes := make([]*appendNode, len(blocks))
+	for i, b := range blocks {
+		b = copyBlock(b)
+		if v := reflect.ValueOf(b); v.Kind() == reflect.Ptr && !v.IsNil() {
+			splitTexts(v.Elem())
+		}
+		n := &appendNode{block: b}
+		if _, _, children := BlockChildren(b); children != nil {
+			n.children = prepareAppend(*children)
+			*children = nil
+		}
+		nodes[i] = n
+	}
+	return nodes
+}
+
+func countNodes(nodes []*appendNode) int {
+	n := len(nodes)
+	for _, node := range nodes {
+		n += countNodes(node.children)
+	}
+	return n
+}
+
+// payload returns the block to send with as many children as the limits
+// allow at depth. budget is the number of blocks which can still be added
+// to the request.
+func (n *appendNode) payload(depth int, budget *int) Block {
+	n.sent = 0
+	if depth >= maxAppendDepth || len(n.children) == 0 {
+		return n.block
+	}
+	k := len(n.children)
+	if k > maxAppendChildren {
+		k = maxAppendChildren
+	}
+	if k > *budget {
+		k = *budget
+	}
+	*budget -= k
+	children := make([]Block, k)
+	for i, child := range n.children[:k] {
+		children[i] = child.payload(depth+1, budget)
+	}
+	n.sent = k
+
+	b := copyBlock(n.block)
+	_, _, field := BlockChildren(b)
+	*field = children
+	return b
+}
+
+// pending reports whether children of n or of its sent children are left
+func (n *appendNode) pending() bool {
+	if n.sent < len(n.children) {
+		return true
+	}
+	for _, child := range n.children[:n.sent] {
+		if child.pending() {
+			return true
+		}
+	}
+	return false
+}
+
+type appender struct {
+	blocks   *BlockClient
+	progress func(appended, total int)
+	total    int
+	appended int
+}
+
+// append appends nodes to parent in batches and then the children left
+// over by each batch
+func (a *appender) append(ctx context.Context, parent BlockID, nodes []*appendNode) error {
+	for len(nodes) > 0 {
+		batch := nodes
+		if len(batch) > maxAppendChildren {
+			batch = batch[:maxAppendChildren]
+		}
+		nodes = nodes[len(batch):]
+
+		budget := maxAppendBlocks - len(batch)
+		payload := make([]Block, len(batch))
+		for i, n := range batch {
+			payload[i] = n.payload(1, &budget)
+		}
+		_, err := a.blocks.AppendChildren(ctx, parent, &AppendBlockChildrenRequest{Children: payload})
+		if err != nil {
+			return err
+		}
+		a.appended += maxAppendBlocks - budget
+		if a.progress != nil {
+			a.progress(a.appended, a.total)
+		}
+
+		if !anyPending(batch) {
+			continue
+		}
+		// the appended blocks are the last children of parent
+		created, err := a.blocks.GetChildrenAll(ctx, parent, nil).Collect(0)
+		if err != nil {
+			return err
+		}
+		if len(created) < len(batch) {
+			return fmt.Errorf("appended %d blocks to %s, but it has %d children", len(batch), parent, len(created))
+		}
+		if err := a.followUp(ctx, batch, created[len(created)-len(batch):]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// followUp appends the children left over by the request which created
+// the blocks of nodes
+func (a *appender) followUp(ctx context.Context, nodes []*appendNode, created []Block) error {
+	for i, n := range nodes {
+		if !n.pending() {
+			continue
+		}
+		id, _, field := BlockChildren(created[i])
+		if field == nil || created[i].GetType() != n.block.GetType() {
+			return fmt.Errorf("appended block of type %q, but found %q", n.block.GetType(), created[i].GetType())
+		}
+
+		sent := n.children[:n.sent]
+		rest := n.children[n.sent:]
+		if anyPending(sent) {
+			children, err := a.blocks.GetChildrenAll(ctx, id, nil).Collect(len(sent))
+			if err != nil {
+				return err
+			}
+			if len(children) < len(sent) {
+				return fmt.Errorf("appended %d blocks to %s, but it has %d children", len(sent), id, len(children))
+			}
+			if err := a.followUp(ctx, sent, children); err != nil {
+				return err
+			}
+		}
+		if err := a.append(ctx, id, rest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func anyPending(nodes []*appendNode) bool {
+	for _, n := range nodes {
+		if n.pending() {
+			return true
+		}
+	}
+	return false
+}
+
+// copyBlock returns a shallow copy of the struct b points to
+func copyBlock(b Block) Block {
+	v := reflect.ValueOf(b)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return b
+	}
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(Block)
+}
+
+// splitTexts splits the long texts in all rich text fields of the struct v
+func splitTexts(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch {
+		case !f.CanSet():
+		case f.Type() == paragraphType:
+			f.Set(reflect.ValueOf(splitText(f.Interface().(Paragraph))))
+		case f.Kind() == reflect.Struct:
+			splitTexts(f)
+		}
+	}
+}
+
+// splitText splits text content longer than maxTextLength into several rich
+// text objects with the same annotations and link
+func splitText(p Paragraph) Paragraph {
+	long := false
+	for _, rt := range p {
+		if utf8.RuneCountInString(rt.Text.Content) > maxTextLength {
+			long = true
+		}
+	}
+	if !long {
+		return p
+	}
+
+	result := make(Paragraph, 0, len(p))
+	for _, rt := range p {
+		if utf8.RuneCountInString(rt.Text.Content) <= maxTextLength {
+			result = append(result, rt)
+			continue
+		}
+		for _, chunk := range splitString(rt.Text.Content, maxTextLength) {
+			part := rt
+			part.Text.Content = chunk
+			part.PlainText = ""
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// splitString splits s into parts of at most n characters. It prefers to
+// split after white space in the second half of a part.
+func splitString(s string, n int) []string {
+	var parts []string
+	for utf8.RuneCountInString(s) > n {
+		end, count, space := 0, 0, 0
+		for i, r := range s {
+			if count == n {
+				end = i
+				break
+			}
+			count++
+			if unicode.IsSpace(r) && count > n/2 {
+				space = i + utf8.RuneLen(r)
+			}
+		}
+		if space > 0 {
+			end = space
+		}
+		parts = append(parts, s[:end])
+		s = s[end:]
+	}
+	return append(parts, s)
+}
+
+// fitsAppend reports whether children can be sent in one request
+func fitsAppend(children []Block) bool {
+	v := &validator{}
+	v.children("children", children)
+	return len(v.fields) == 0
+}
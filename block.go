@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"sync"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 type BlockID string
@@ -15,32 +19,147 @@ func (bID BlockID) String() string {
 }
 
 type BlockService interface {
+	Get(context.Context, BlockID) (Block, error)
+	Update(context.Context, BlockID, *BlockUpdateRequest) (Block, error)
+	Delete(context.Context, BlockID) (Block, error)
 	GetChildren(context.Context, BlockID, *Pagination) (*GetChildrenResponse, error)
 	AppendChildren(context.Context, BlockID, *AppendBlockChildrenRequest) (Block, error)
+	GetChildrenAll(context.Context, BlockID, *Pagination) *BlockIterator
+	GetTree(context.Context, BlockID, *GetTreeOptions) ([]Block, error)
+	AppendAll(context.Context, BlockID, []Block, *AppendAllOptions) error
 }
 
 type BlockClient struct {
 	apiClient *Client
 }
 
+// Get https://developers.notion.com/reference/retrieve-a-block
+func (bc *BlockClient) Get(ctx context.Context, id BlockID) (Block, error) {
+	return bc.callBlock(ctx, &Call{
+		Operation: "blocks.get",
+		ObjectID:  id.String(),
+		Method:    http.MethodGet,
+		Path:      fmt.Sprintf("blocks/%s", id.String()),
+	})
+}
+
+// Update https://developers.notion.com/reference/update-a-block
+func (bc *BlockClient) Update(ctx context.Context, id BlockID, requestBody *BlockUpdateRequest) (Block, error) {
+	return bc.callBlock(ctx, &Call{
+		Operation: "blocks.update",
+		ObjectID:  id.String(),
+		Method:    http.MethodPatch,
+		Path:      fmt.Sprintf("blocks/%s", id.String()),
+		Body:      requestBody,
+	})
+}
+
+// Delete archives a block https://developers.notion.com/reference/delete-a-block
+func (bc *BlockClient) Delete(ctx context.Context, id BlockID) (Block, error) {
+	return bc.callBlock(ctx, &Call{
+		Operation: "blocks.delete",
+		ObjectID:  id.String(),
+		Method:    http.MethodDelete,
+		Path:      fmt.Sprintf("blocks/%s", id.String()),
+	})
+}
+
+func (bc *BlockClient) callBlock(ctx context.Context, call *Call) (Block, error) {
+	call.decode = decodeBlockResponse
+	res, err := bc.apiClient.call(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+
+	response, ok := res.(Block)
+	if !ok {
+		return nil, unexpectedResponse(call, res)
+	}
+	return response, nil
+}
+
+// BlockUpdateRequest replaces the content of a block and archives or restores
+// it. Only the type specific content of Block is sent, e.g. the text and
+// checked state of a to_do block, so Block must have its Type set. Children
+// can not be updated and are left out. Block can be nil to only change
+// Archived.
+type BlockUpdateRequest struct {
+	Block Block
+	// Archived archives the block if true and restores it if false. Nil
+	// leaves it unchanged.
+	Archived *bool
+}
+
+func (r BlockUpdateRequest) MarshalJSON() ([]byte, error) {
+	body := map[string]interface{}{}
+	if r.Archived != nil {
+		body["archived"] = *r.Archived
+	}
+	if r.Block == nil {
+		if r.Archived == nil {
+			return nil, errors.New("block update request without block or archived")
+		}
+		return json.Marshal(body)
+	}
+
+	j, err := json.Marshal(r.Block)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(j, &fields); err != nil {
+		return nil, err
+	}
+	blockType := r.Block.GetType().String()
+	raw, ok := fields[blockType]
+	if !ok {
+		return nil, errors.Errorf("block of type %q has no content to update", blockType)
+	}
+	var content map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return nil, errors.Wrapf(err, "content of %s block", blockType)
+	}
+	delete(content, "children")
+	body[blockType] = content
+	return json.Marshal(body)
+}
+
 // GetChildren https://developers.notion.com/reference/get-block-children
 func (bc *BlockClient) GetChildren(ctx context.Context, id BlockID, pagination *Pagination) (*GetChildrenResponse, error) {
-	res, err := bc.apiClient.request(ctx, http.MethodGet, fmt.Sprintf("blocks/%s/children", id.String()), pagination.ToQuery(), nil)
+	call := &Call{
+		Operation: "blocks.children.get",
+		ObjectID:  id.String(),
+		Method:    http.MethodGet,
+		Path:      fmt.Sprintf("blocks/%s/children", id.String()),
+		Query:     pagination.ToQuery(),
+		decode:    decodeGetChildrenResponse,
+	}
+	res, err := bc.apiClient.call(ctx, call)
 	if err != nil {
 		return nil, err
 	}
 
+	response, ok := res.(*GetChildrenResponse)
+	if !ok {
+		return nil, unexpectedResponse(call, res)
+	}
+	return response, nil
+}
+
+func decodeGetChildrenResponse(r io.Reader) (interface{}, error) {
 	var response struct {
-		Object  ObjectType `json:"object"`
-		Results []map[string]interface{}
+		Object     ObjectType `json:"object"`
+		Results    []json.RawMessage
+		HasMore    bool   `json:"has_more"`
+		NextCursor Cursor `json:"next_cursor"`
 	}
-	err = json.NewDecoder(res.Body).Decode(&response)
+	err := json.NewDecoder(r).Decode(&response)
 	if err != nil {
 		return nil, err
 	}
 	results := make([]Block, len(response.Results))
-	for i, r := range response.Results {
-		b, err := decodeBlock(r)
+	for i, raw := range response.Results {
+		b, err := decodeBlock(raw)
 		if err != nil {
 			return nil, err
 		}
@@ -48,29 +167,141 @@ func (bc *BlockClient) GetChildren(ctx context.Context, id BlockID, pagination *
 	}
 
 	return &GetChildrenResponse{
-		Object:  response.Object,
-		Results: results,
+		Object:     response.Object,
+		Results:    results,
+		HasMore:    response.HasMore,
+		NextCursor: response.NextCursor,
 	}, nil
 }
 
 type GetChildrenResponse struct {
-	Object  ObjectType `json:"object"`
-	Results []Block    `json:"results"`
+	Object     ObjectType `json:"object"`
+	Results    []Block    `json:"results"`
+	HasMore    bool       `json:"has_more"`
+	NextCursor Cursor     `json:"next_cursor"`
+}
+
+// GetChildrenAll iterates over all children of a block, starting at
+// pagination.StartCursor if set
+func (bc *BlockClient) GetChildrenAll(ctx context.Context, id BlockID, pagination *Pagination) *BlockIterator {
+	it := &BlockIterator{}
+	it.iterator = newIterator(ctx, startCursor(pagination), func(ctx context.Context, cursor Cursor) (int, Cursor, bool, error) {
+		res, err := bc.GetChildren(ctx, id, paginationFrom(pagination, cursor))
+		if err != nil {
+			return 0, "", false, err
+		}
+		it.items = res.Results
+		return len(res.Results), res.NextCursor, res.HasMore, nil
+	})
+	return it
 }
 
 // AppendChildren https://developers.notion.com/reference/patch-block-children
 func (bc *BlockClient) AppendChildren(ctx context.Context, id BlockID, requestBody *AppendBlockChildrenRequest) (Block, error) {
-	res, err := bc.apiClient.request(ctx, http.MethodPatch, fmt.Sprintf("blocks/%s/children", id.String()), nil, requestBody)
+	return bc.callBlock(ctx, &Call{
+		Operation: "blocks.children.append",
+		ObjectID:  id.String(),
+		Method:    http.MethodPatch,
+		Path:      fmt.Sprintf("blocks/%s/children", id.String()),
+		Body:      requestBody,
+	})
+}
+
+func decodeBlockResponse(r io.Reader) (interface{}, error) {
+	var response json.RawMessage
+	err := json.NewDecoder(r).Decode(&response)
 	if err != nil {
 		return nil, err
 	}
+	return decodeBlock(response)
+}
+
+// GetTreeOptions configures BlockService.GetTree
+type GetTreeOptions struct {
+	// MaxDepth limits how many levels of blocks are fetched. 1 returns only
+	// the direct children of the root block. Zero means no limit.
+	MaxDepth int
+	// Concurrency is the maximum number of parallel requests. Defaults to 1.
+	Concurrency int
+}
+
+// GetTree fetches the children of a block recursively and attaches them to
+// the Children field of their parent. Child pages are not descended into.
+func (bc *BlockClient) GetTree(ctx context.Context, id BlockID, opts *GetTreeOptions) ([]Block, error) {
+	var options GetTreeOptions
+	if opts != nil {
+		options = *opts
+	}
+	if options.Concurrency < 1 {
+		options.Concurrency = 1
+	}
+
+	f := &treeFetcher{
+		blocks:   bc,
+		maxDepth: options.MaxDepth,
+		sem:      make(chan struct{}, options.Concurrency),
+	}
+	if err := f.acquire(ctx); err != nil {
+		return nil, err
+	}
+	return f.children(ctx, id, 1)
+}
 
-	var response map[string]interface{}
-	err = json.NewDecoder(res.Body).Decode(&response)
+// treeFetcher fetches a tree of blocks. Every request holds a slot of sem,
+// which is acquired before its goroutine is started, so at most Concurrency
+// goroutines fetch at a time and the others wait for their children.
+type treeFetcher struct {
+	blocks   *BlockClient
+	maxDepth int
+	sem      chan struct{}
+}
+
+func (f *treeFetcher) acquire(ctx context.Context) error {
+	select {
+	case f.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// children fetches the children of id with a slot acquired by the caller and
+// releases it before descending. Subtrees are fetched to the end even if
+// another one fails, so that the first error in tree order is returned.
+func (f *treeFetcher) children(ctx context.Context, id BlockID, depth int) ([]Block, error) {
+	blocks, err := f.blocks.GetChildrenAll(ctx, id, nil).Collect(0)
+	<-f.sem
 	if err != nil {
 		return nil, err
 	}
-	return decodeBlock(response)
+	if f.maxDepth > 0 && depth >= f.maxDepth {
+		return blocks, nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(blocks))
+	for i, b := range blocks {
+		childID, hasChildren, children := BlockChildren(b)
+		if !hasChildren || children == nil {
+			continue
+		}
+		if errs[i] = f.acquire(ctx); errs[i] != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int, childID BlockID, children *[]Block) {
+			defer wg.Done()
+			*children, errs[i] = f.children(ctx, childID, depth+1)
+		}(i, childID, children)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return blocks, nil
 }
 
 type BlockType string
@@ -94,6 +325,7 @@ type ParagraphBlock struct {
 	CreatedTime    *time.Time `json:"created_time,omitempty"`
 	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
 	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
 	Paragraph      struct {
 		Text     Paragraph `json:"text"`
 		Children []Block   `json:"children,omitempty"`
@@ -111,6 +343,7 @@ type Heading1Block struct {
 	CreatedTime    *time.Time `json:"created_time,omitempty"`
 	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
 	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
 	Heading1       struct {
 		Text Paragraph `json:"text"`
 	} `json:"heading_1"`
@@ -127,6 +360,7 @@ type Heading2Block struct {
 	CreatedTime    *time.Time `json:"created_time,omitempty"`
 	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
 	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
 	Heading2       struct {
 		Text Paragraph `json:"text"`
 	} `json:"heading_2"`
@@ -143,6 +377,7 @@ type Heading3Block struct {
 	CreatedTime    *time.Time `json:"created_time,omitempty"`
 	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
 	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
 	Heading3       struct {
 		Text Paragraph `json:"text"`
 	} `json:"heading_3"`
@@ -159,6 +394,7 @@ type BulletedListItemBlock struct {
 	CreatedTime      *time.Time `json:"created_time,omitempty"`
 	LastEditedTime   *time.Time `json:"last_edited_time,omitempty"`
 	HasChildren      bool       `json:"has_children,omitempty"`
+	Archived         bool       `json:"archived,omitempty"`
 	BulletedListItem struct {
 		Text     Paragraph `json:"text"`
 		Children []Block   `json:"children,omitempty"`
@@ -176,6 +412,7 @@ type NumberedListItemBlock struct {
 	CreatedTime      *time.Time `json:"created_time,omitempty"`
 	LastEditedTime   *time.Time `json:"last_edited_time,omitempty"`
 	HasChildren      bool       `json:"has_children,omitempty"`
+	Archived         bool       `json:"archived,omitempty"`
 	NumberedListItem struct {
 		Text     Paragraph `json:"text"`
 		Children []Block   `json:"children,omitempty"`
@@ -193,6 +430,7 @@ type ToDoBlock struct {
 	CreatedTime    *time.Time `json:"created_time,omitempty"`
 	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
 	HasChildren    bool       `json:"has_children"`
+	Archived       bool       `json:"archived,omitempty"`
 	ToDo           struct {
 		Text     Paragraph `json:"text"`
 		Children []Block   `json:"children,omitempty"`
@@ -211,6 +449,7 @@ type ToggleBlock struct {
 	CreatedTime    *time.Time `json:"created_time,omitempty"`
 	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
 	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
 	Text           Paragraph  `json:"text"`
 	Children       []Block    `json:"children,omitempty"`
 	Toggle         struct {
@@ -230,6 +469,7 @@ type ChildPageBlock struct {
 	CreatedTime    *time.Time `json:"created_time,omitempty"`
 	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
 	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
 	ChildPage      struct {
 		Title string `json:"title"`
 	} `json:"child_page"`
@@ -239,9 +479,345 @@ func (b *ChildPageBlock) GetType() BlockType {
 	return b.Type
 }
 
-func decodeBlock(raw map[string]interface{}) (Block, error) {
+// BlockFile is the content of image, video, file and pdf blocks
+type BlockFile struct {
+	Caption  Paragraph   `json:"caption,omitempty"`
+	Type     FileType    `json:"type"`
+	File     *FileObject `json:"file,omitempty"`
+	External *FileObject `json:"external,omitempty"`
+}
+
+type ImageBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	Image          BlockFile  `json:"image"`
+}
+
+func (b *ImageBlock) GetType() BlockType {
+	return b.Type
+}
+
+type VideoBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	Video          BlockFile  `json:"video"`
+}
+
+func (b *VideoBlock) GetType() BlockType {
+	return b.Type
+}
+
+type FileBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	File           BlockFile  `json:"file"`
+}
+
+func (b *FileBlock) GetType() BlockType {
+	return b.Type
+}
+
+type PdfBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	Pdf            BlockFile  `json:"pdf"`
+}
+
+func (b *PdfBlock) GetType() BlockType {
+	return b.Type
+}
+
+type BookmarkBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	Bookmark       struct {
+		URL     string    `json:"url"`
+		Caption Paragraph `json:"caption,omitempty"`
+	} `json:"bookmark"`
+}
+
+func (b *BookmarkBlock) GetType() BlockType {
+	return b.Type
+}
+
+type EmbedBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	Embed          struct {
+		URL string `json:"url"`
+	} `json:"embed"`
+}
+
+func (b *EmbedBlock) GetType() BlockType {
+	return b.Type
+}
+
+type CalloutBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	Callout        struct {
+		Text     Paragraph `json:"text"`
+		Icon     *Icon     `json:"icon,omitempty"`
+		Children []Block   `json:"children,omitempty"`
+	} `json:"callout"`
+}
+
+func (b *CalloutBlock) GetType() BlockType {
+	return b.Type
+}
+
+type QuoteBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	Quote          struct {
+		Text     Paragraph `json:"text"`
+		Children []Block   `json:"children,omitempty"`
+	} `json:"quote"`
+}
+
+func (b *QuoteBlock) GetType() BlockType {
+	return b.Type
+}
+
+type CodeBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	Code           struct {
+		Text     Paragraph `json:"text"`
+		Caption  Paragraph `json:"caption,omitempty"`
+		Language string    `json:"language"`
+	} `json:"code"`
+}
+
+func (b *CodeBlock) GetType() BlockType {
+	return b.Type
+}
+
+type EquationBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	Equation       struct {
+		Expression string `json:"expression"`
+	} `json:"equation"`
+}
+
+func (b *EquationBlock) GetType() BlockType {
+	return b.Type
+}
+
+type DividerBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	Divider        struct{}   `json:"divider"`
+}
+
+func (b *DividerBlock) GetType() BlockType {
+	return b.Type
+}
+
+type TableOfContentsBlock struct {
+	Object          ObjectType `json:"object"`
+	ID              BlockID    `json:"id,omitempty"`
+	Type            BlockType  `json:"type"`
+	CreatedTime     *time.Time `json:"created_time,omitempty"`
+	LastEditedTime  *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren     bool       `json:"has_children,omitempty"`
+	Archived        bool       `json:"archived,omitempty"`
+	TableOfContents struct{}   `json:"table_of_contents"`
+}
+
+func (b *TableOfContentsBlock) GetType() BlockType {
+	return b.Type
+}
+
+type BreadcrumbBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	Breadcrumb     struct{}   `json:"breadcrumb"`
+}
+
+func (b *BreadcrumbBlock) GetType() BlockType {
+	return b.Type
+}
+
+type LinkPreviewBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	LinkPreview    struct {
+		URL string `json:"url"`
+	} `json:"link_preview"`
+}
+
+func (b *LinkPreviewBlock) GetType() BlockType {
+	return b.Type
+}
+
+type LinkToPageBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	LinkToPage     Parent     `json:"link_to_page"`
+}
+
+func (b *LinkToPageBlock) GetType() BlockType {
+	return b.Type
+}
+
+type ChildDatabaseBlock struct {
+	Object         ObjectType `json:"object"`
+	ID             BlockID    `json:"id,omitempty"`
+	Type           BlockType  `json:"type"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+	ChildDatabase  struct {
+		Title string `json:"title"`
+	} `json:"child_database"`
+}
+
+func (b *ChildDatabaseBlock) GetType() BlockType {
+	return b.Type
+}
+
+// BlockChildren returns the ID of b, whether it has children and a pointer to
+// the field holding them, e.g. to walk a tree fetched by GetTree. children is
+// nil if the block type can not hold any.
+func BlockChildren(b Block) (id BlockID, hasChildren bool, children *[]Block) {
+	switch b := b.(type) {
+	case *ParagraphBlock:
+		return b.ID, b.HasChildren, &b.Paragraph.Children
+	case *BulletedListItemBlock:
+		return b.ID, b.HasChildren, &b.BulletedListItem.Children
+	case *NumberedListItemBlock:
+		return b.ID, b.HasChildren, &b.NumberedListItem.Children
+	case *ToDoBlock:
+		return b.ID, b.HasChildren, &b.ToDo.Children
+	case *ToggleBlock:
+		return b.ID, b.HasChildren, &b.Toggle.Children
+	case *CalloutBlock:
+		return b.ID, b.HasChildren, &b.Callout.Children
+	case *QuoteBlock:
+		return b.ID, b.HasChildren, &b.Quote.Children
+	}
+	return "", false, nil
+}
+
+// UnknownBlock holds a block of a type this library does not support yet.
+// It keeps the original JSON and marshals back to it unchanged.
+type UnknownBlock struct {
+	Object         ObjectType      `json:"object"`
+	ID             BlockID         `json:"id,omitempty"`
+	Type           BlockType       `json:"type"`
+	CreatedTime    *time.Time      `json:"created_time,omitempty"`
+	LastEditedTime *time.Time      `json:"last_edited_time,omitempty"`
+	HasChildren    bool            `json:"has_children,omitempty"`
+	Archived       bool            `json:"archived,omitempty"`
+	Raw            json.RawMessage `json:"-"`
+}
+
+func (b *UnknownBlock) GetType() BlockType {
+	return b.Type
+}
+
+func (b *UnknownBlock) UnmarshalJSON(data []byte) error {
+	type block UnknownBlock
+	var tmp block
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*b = UnknownBlock(tmp)
+	b.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+func (b *UnknownBlock) MarshalJSON() ([]byte, error) {
+	if b.Raw != nil {
+		return b.Raw, nil
+	}
+	type block UnknownBlock
+	return json.Marshal((*block)(b))
+}
+
+func decodeBlock(raw json.RawMessage) (Block, error) {
+	var header struct {
+		Type BlockType `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, err
+	}
+
 	var b Block
-	switch BlockType(raw["type"].(string)) {
+	switch header.Type {
 	case BlockTypeParagraph:
 		b = &ParagraphBlock{}
 	case BlockTypeHeading1:
@@ -249,7 +825,7 @@ func decodeBlock(raw map[string]interface{}) (Block, error) {
 	case BlockTypeHeading2:
 		b = &Heading2Block{}
 	case BlockTypeHeading3:
-		b = &Heading2Block{}
+		b = &Heading3Block{}
 	case BlockTypeBulletedListItem:
 		b = &BulletedListItemBlock{}
 	case BlockTypeNumberedListItem:
@@ -260,14 +836,42 @@ func decodeBlock(raw map[string]interface{}) (Block, error) {
 		b = &ToggleBlock{}
 	case BlockTypeChildPage:
 		b = &ChildPageBlock{}
+	case BlockTypeImage:
+		b = &ImageBlock{}
+	case BlockTypeVideo:
+		b = &VideoBlock{}
+	case BlockTypeFile:
+		b = &FileBlock{}
+	case BlockTypePdf:
+		b = &PdfBlock{}
+	case BlockTypeBookmark:
+		b = &BookmarkBlock{}
+	case BlockTypeEmbed:
+		b = &EmbedBlock{}
+	case BlockTypeCallout:
+		b = &CalloutBlock{}
+	case BlockTypeQuote:
+		b = &QuoteBlock{}
+	case BlockTypeCode:
+		b = &CodeBlock{}
+	case BlockTypeEquation:
+		b = &EquationBlock{}
+	case BlockTypeDivider:
+		b = &DividerBlock{}
+	case BlockTypeTableOfContents:
+		b = &TableOfContentsBlock{}
+	case BlockTypeBreadcrumb:
+		b = &BreadcrumbBlock{}
+	case BlockTypeLinkPreview:
+		b = &LinkPreviewBlock{}
+	case BlockTypeLinkToPage:
+		b = &LinkToPageBlock{}
+	case BlockTypeChildDatabase:
+		b = &ChildDatabaseBlock{}
 	default:
-		return nil, fmt.Errorf("unsupported block type: %s", raw["type"].(string))
-	}
-	j, err := json.Marshal(raw)
-	if err != nil {
-		return nil, err
+		b = &UnknownBlock{}
 	}
 
-	err = json.Unmarshal(j, b)
+	err := json.Unmarshal(raw, b)
 	return b, err
 }
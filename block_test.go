@@ -2,9 +2,16 @@ package notionapi_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/jomei/notionapi"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"reflect"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -14,6 +21,181 @@ func TestBlockClient(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	t.Run("Get", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			filePath   string
+			statusCode int
+			id         notionapi.BlockID
+			want       notionapi.Block
+			wantErr    bool
+			err        error
+		}{
+			{
+				name:       "returns block by id",
+				id:         "some_id",
+				filePath:   "testdata/block_get.json",
+				statusCode: http.StatusOK,
+				want:       toDoBlock(timestamp, "todo", false),
+			},
+			{
+				name:       "returns validation error for invalid request",
+				id:         "some_id",
+				filePath:   "testdata/validation_error.json",
+				statusCode: http.StatusBadRequest,
+				wantErr:    true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				c := newMockedClient(t, tt.filePath, tt.statusCode)
+				client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+				got, err := client.Block.Get(context.Background(), tt.id)
+
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Get() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		archived, unarchived := true, false
+		tests := []struct {
+			name       string
+			filePath   string
+			statusCode int
+			id         notionapi.BlockID
+			request    *notionapi.BlockUpdateRequest
+			wantBody   string
+			want       notionapi.Block
+			wantErr    bool
+			err        error
+		}{
+			{
+				name:       "updates block content",
+				id:         "some_id",
+				filePath:   "testdata/block_update.json",
+				statusCode: http.StatusOK,
+				request: &notionapi.BlockUpdateRequest{
+					Block: toDoBlock(time.Time{}, "done", true),
+				},
+				wantBody: `{"to_do":{"checked":true,"text":[{"type":"text","text":{"content":"done"},"annotations":{"bold":false,"italic":false,"strikethrough":false,"underline":false,"code":false,"color":"default"},"plain_text":"done"}]}}`,
+				want:     toDoBlock(timestamp, "done", true),
+			},
+			{
+				name:       "leaves out children",
+				id:         "some_id",
+				filePath:   "testdata/block_update.json",
+				statusCode: http.StatusOK,
+				request: &notionapi.BlockUpdateRequest{
+					Block: &notionapi.ParagraphBlock{
+						Type: notionapi.BlockTypeParagraph,
+						Paragraph: struct {
+							Text     notionapi.Paragraph `json:"text"`
+							Children []notionapi.Block   `json:"children,omitempty"`
+						}{Children: []notionapi.Block{toDoBlock(time.Time{}, "done", true)}},
+					},
+					Archived: &unarchived,
+				},
+				wantBody: `{"archived":false,"paragraph":{"text":null}}`,
+				want:     toDoBlock(timestamp, "done", true),
+			},
+			{
+				name:       "archives block",
+				id:         "some_id",
+				filePath:   "testdata/block_update.json",
+				statusCode: http.StatusOK,
+				request:    &notionapi.BlockUpdateRequest{Archived: &archived},
+				wantBody:   `{"archived":true}`,
+				want:       toDoBlock(timestamp, "done", true),
+			},
+			{
+				name:    "fails without block and archived",
+				id:      "some_id",
+				request: &notionapi.BlockUpdateRequest{},
+				wantErr: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var body []byte
+				c := newTestClient(func(req *http.Request) *http.Response {
+					var err error
+					body, err = ioutil.ReadAll(req.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return newMockedClientResponse(t, tt.filePath, tt.statusCode)
+				})
+				client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+				got, err := client.Block.Update(context.Background(), tt.id, tt.request)
+
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+				if tt.wantErr {
+					return
+				}
+				if string(body) != tt.wantBody {
+					t.Errorf("Update() request body got = %s, want %s", body, tt.wantBody)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Update() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			filePath   string
+			statusCode int
+			id         notionapi.BlockID
+			wantErr    bool
+			err        error
+		}{
+			{
+				name:       "archives block",
+				id:         "some_id",
+				filePath:   "testdata/block_delete.json",
+				statusCode: http.StatusOK,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var method string
+				c := newTestClient(func(req *http.Request) *http.Response {
+					method = req.Method
+					return newMockedClientResponse(t, tt.filePath, tt.statusCode)
+				})
+				client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+				got, err := client.Block.Delete(context.Background(), tt.id)
+
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+				if method != http.MethodDelete {
+					t.Errorf("Delete() method got = %s, want %s", method, http.MethodDelete)
+				}
+				b, ok := got.(*notionapi.ParagraphBlock)
+				if !ok || !b.Archived {
+					t.Errorf("Delete() got = %v, want archived paragraph", got)
+				}
+			})
+		}
+	})
+
 	t.Run("GetChildren", func(t *testing.T) {
 		tests := []struct {
 			name       string
@@ -21,6 +203,8 @@ func TestBlockClient(t *testing.T) {
 			statusCode int
 			id         notionapi.BlockID
 			len        int
+			hasMore    bool
+			nextCursor notionapi.Cursor
 			wantErr    bool
 			err        error
 		}{
@@ -31,6 +215,15 @@ func TestBlockClient(t *testing.T) {
 				filePath:   "testdata/block_get_children.json",
 				len:        2,
 			},
+			{
+				name:       "returns pagination of children",
+				id:         "some_id",
+				statusCode: http.StatusOK,
+				filePath:   "testdata/block_get_children_has_more.json",
+				len:        1,
+				hasMore:    true,
+				nextCursor: "some_cursor",
+			},
 		}
 
 		for _, tt := range tests {
@@ -47,6 +240,9 @@ func TestBlockClient(t *testing.T) {
 				if tt.len != len(got.Results) {
 					t.Errorf("GetChildren got %d, want: %d", len(got.Results), tt.len)
 				}
+				if got.HasMore != tt.hasMore || got.NextCursor != tt.nextCursor {
+					t.Errorf("GetChildren got has_more %v next_cursor %q, want: %v %q", got.HasMore, got.NextCursor, tt.hasMore, tt.nextCursor)
+				}
 			})
 		}
 	})
@@ -116,3 +312,429 @@ func TestBlockClient(t *testing.T) {
 		}
 	})
 }
+
+func toDoBlock(timestamp time.Time, text string, checked bool) *notionapi.ToDoBlock {
+	b := &notionapi.ToDoBlock{
+		Object: notionapi.ObjectTypeBlock,
+		Type:   notionapi.BlockTypeToDo,
+	}
+	if !timestamp.IsZero() {
+		b.ID = "some_id"
+		b.CreatedTime = &timestamp
+		b.LastEditedTime = &timestamp
+	}
+	b.ToDo.Text = notionapi.Paragraph{
+		{
+			Type:        notionapi.ObjectTypeText,
+			Text:        notionapi.Text{Content: text},
+			Annotations: &notionapi.Annotations{Color: notionapi.ColorDefault},
+			PlainText:   text,
+		},
+	}
+	b.ToDo.Checked = checked
+	return b
+}
+
+func TestBlockClient_GetTree(t *testing.T) {
+	files := map[string]string{
+		"/v1/blocks/root_id/children":   "testdata/block_get_tree_root.json",
+		"/v1/blocks/toggle_id/children": "testdata/block_get_tree_toggle.json",
+		"/v1/blocks/item_id/children":   "testdata/block_get_children.json",
+	}
+
+	tests := []struct {
+		name    string
+		opts    *notionapi.GetTreeOptions
+		files   map[string]string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "fetches all levels",
+			want: "toggle(bulleted_list_item(heading_1 to_do)) paragraph",
+		},
+		{
+			name: "fetches levels concurrently",
+			opts: &notionapi.GetTreeOptions{Concurrency: 4},
+			want: "toggle(bulleted_list_item(heading_1 to_do)) paragraph",
+		},
+		{
+			name: "stops at max depth",
+			opts: &notionapi.GetTreeOptions{MaxDepth: 2},
+			want: "toggle(bulleted_list_item) paragraph",
+		},
+		{
+			name: "returns errors of nested requests",
+			files: map[string]string{
+				"/v1/blocks/root_id/children":   "testdata/block_get_tree_root.json",
+				"/v1/blocks/toggle_id/children": "testdata/validation_error.json",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.files == nil {
+				tt.files = files
+			}
+			c := newTestClient(func(req *http.Request) *http.Response {
+				statusCode := http.StatusOK
+				file, ok := tt.files[req.URL.Path]
+				if !ok || file == "testdata/validation_error.json" {
+					statusCode = http.StatusBadRequest
+					file = "testdata/validation_error.json"
+				}
+				b, err := os.Open(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return &http.Response{StatusCode: statusCode, Body: b, Header: make(http.Header)}
+			})
+			client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+
+			got, err := client.Block.GetTree(context.Background(), "root_id", tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetTree() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if s := formatTree(got); s != tt.want {
+				t.Errorf("GetTree() got = %s, want %s", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockClient_GetTree_Wide(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight, maxGoroutines := 0, 0, 0
+	baseline := runtime.NumGoroutine()
+	c := newTestClient(func(req *http.Request) *http.Response {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		if n := runtime.NumGoroutine() - baseline; n > maxGoroutines {
+			maxGoroutines = n
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		id := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/v1/blocks/"), "/children")
+		var body string
+		switch id {
+		case "root_id":
+			results := make([]string, 50)
+			for i := range results {
+				results[i] = fmt.Sprintf(`{"object":"block","id":"toggle_%d","type":"toggle","has_children":true,"toggle":{"text":[]}}`, i)
+			}
+			body = `{"object":"list","results":[` + strings.Join(results, ",") + `],"has_more":false}`
+		case "toggle_7", "toggle_30":
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"object":"error","status":400,"code":"validation_error","message":"` + id + `"}`)),
+				Header:     make(http.Header),
+			}
+		default:
+			body = `{"object":"list","results":[],"has_more":false}`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body)), Header: make(http.Header)}
+	})
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+
+	_, err := client.Block.GetTree(context.Background(), "root_id", &notionapi.GetTreeOptions{Concurrency: 4})
+	if err == nil || err.Error() != "toggle_7" {
+		t.Errorf("GetTree() error = %v, want the error of toggle_7", err)
+	}
+	if maxInFlight > 4 {
+		t.Errorf("GetTree() sent %d requests at a time, want at most 4", maxInFlight)
+	}
+	if maxGoroutines > 8 {
+		t.Errorf("GetTree() started %d goroutines", maxGoroutines)
+	}
+}
+
+// formatTree prints the types of blocks with children in parentheses
+func formatTree(blocks []notionapi.Block) string {
+	parts := make([]string, len(blocks))
+	for i, b := range blocks {
+		var children []notionapi.Block
+		switch b := b.(type) {
+		case *notionapi.ToggleBlock:
+			children = b.Toggle.Children
+		case *notionapi.BulletedListItemBlock:
+			children = b.BulletedListItem.Children
+		}
+		parts[i] = b.GetType().String()
+		if len(children) > 0 {
+			parts[i] += "(" + formatTree(children) + ")"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestBlock_JSONRoundTrip(t *testing.T) {
+	filePath := "testdata/block_get_children_all_types.json"
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw struct {
+		Results []map[string]interface{} `json:"results"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []notionapi.Block{
+		&notionapi.ImageBlock{},
+		&notionapi.VideoBlock{},
+		&notionapi.FileBlock{},
+		&notionapi.PdfBlock{},
+		&notionapi.BookmarkBlock{},
+		&notionapi.EmbedBlock{},
+		&notionapi.CalloutBlock{},
+		&notionapi.QuoteBlock{},
+		&notionapi.CodeBlock{},
+		&notionapi.EquationBlock{},
+		&notionapi.DividerBlock{},
+		&notionapi.TableOfContentsBlock{},
+		&notionapi.BreadcrumbBlock{},
+		&notionapi.LinkPreviewBlock{},
+		&notionapi.LinkToPageBlock{},
+		&notionapi.ChildDatabaseBlock{},
+	}
+
+	c := newMockedClient(t, filePath, http.StatusOK)
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+	got, err := client.Block.GetChildren(context.Background(), "some_id", nil)
+	if err != nil {
+		t.Fatalf("GetChildren() error = %v", err)
+	}
+	if len(got.Results) != len(want) {
+		t.Fatalf("GetChildren() got %d blocks, want %d", len(got.Results), len(want))
+	}
+
+	for i, b := range got.Results {
+		blockType := raw.Results[i]["type"].(string)
+		t.Run(blockType, func(t *testing.T) {
+			if reflect.TypeOf(b) != reflect.TypeOf(want[i]) {
+				t.Fatalf("decoded %s as %T, want %T", blockType, b, want[i])
+			}
+			if b.GetType().String() != blockType {
+				t.Errorf("GetType() got = %s, want %s", b.GetType(), blockType)
+			}
+
+			j, err := json.Marshal(b)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var marshalled map[string]interface{}
+			if err := json.Unmarshal(j, &marshalled); err != nil {
+				t.Fatal(err)
+			}
+			for _, key := range []string{"object", "id", "type", "created_time", blockType} {
+				if !reflect.DeepEqual(marshalled[key], raw.Results[i][key]) {
+					t.Errorf("Marshal() %s got = %v, want %v", key, marshalled[key], raw.Results[i][key])
+				}
+			}
+		})
+	}
+}
+
+func TestUnknownBlock(t *testing.T) {
+	filePath := "testdata/block_get_children_unknown.json"
+	want := map[string]interface{}{
+		"object":           "block",
+		"id":               "synced_id",
+		"created_time":     "2021-05-30T09:47:03.727Z",
+		"last_edited_time": "2021-05-30T09:47:00.000Z",
+		"has_children":     true,
+		"type":             "synced_block",
+		"synced_block": map[string]interface{}{
+			"synced_from": map[string]interface{}{"type": "block_id", "block_id": "some_id"},
+		},
+	}
+
+	t.Run("keeps raw json", func(t *testing.T) {
+		c := newMockedClient(t, filePath, http.StatusOK)
+		client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+		got, err := client.Block.GetChildren(context.Background(), "some_id", nil)
+		if err != nil {
+			t.Fatalf("GetChildren() error = %v", err)
+		}
+
+		b, ok := got.Results[1].(*notionapi.UnknownBlock)
+		if !ok {
+			t.Fatalf("GetChildren() got %T, want *notionapi.UnknownBlock", got.Results[1])
+		}
+		if b.ID != "synced_id" || b.Type != "synced_block" || !b.HasChildren {
+			t.Errorf("GetChildren() got = %+v", b)
+		}
+
+		j, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		var marshalled map[string]interface{}
+		if err := json.Unmarshal(j, &marshalled); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(marshalled, want) {
+			t.Errorf("Marshal() got = %s", j)
+		}
+	})
+
+	t.Run("fails in strict mode", func(t *testing.T) {
+		c := newMockedClient(t, filePath, http.StatusOK)
+		client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c), notionapi.WithStrictDecoding())
+		_, err := client.Block.GetChildren(context.Background(), "some_id", nil)
+		if err == nil || err.Error() != "unsupported block type: synced_block" {
+			t.Errorf("GetChildren() error = %v", err)
+		}
+	})
+}
+
+// blockStore fakes the children endpoints. Appended blocks get sequential
+// IDs and are returned by GetChildren.
+type blockStore struct {
+	t        *testing.T
+	children map[string][]map[string]interface{}
+	nextID   int
+}
+
+func (s *blockStore) roundTrip(req *http.Request) *http.Response {
+	id := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/v1/blocks/"), "/children")
+	var body interface{}
+	switch req.Method {
+	case http.MethodPatch:
+		var request struct {
+			Children []map[string]interface{} `json:"children"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			s.t.Fatal(err)
+		}
+		s.store(id, request.Children, 1)
+		return newMockedClientResponse(s.t, "testdata/block_append_children.json", http.StatusOK)
+	case http.MethodGet:
+		body = map[string]interface{}{"object": "list", "results": s.children[id], "has_more": false, "next_cursor": nil}
+	}
+	j, err := json.Marshal(body)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(string(j))), Header: make(http.Header)}
+}
+
+// store checks the limits of a request and saves the blocks
+func (s *blockStore) store(parent string, blocks []map[string]interface{}, depth int) {
+	if len(blocks) > 100 {
+		s.t.Errorf("appended %d children to %s", len(blocks), parent)
+	}
+	if depth > 2 {
+		s.t.Errorf("appended children at depth %d", depth)
+	}
+	for _, b := range blocks {
+		s.nextID++
+		id := fmt.Sprintf("block_%d", s.nextID)
+		b["object"], b["id"] = "block", id
+		content := b[b["type"].(string)].(map[string]interface{})
+		for _, rt := range content["text"].([]interface{}) {
+			if text := rt.(map[string]interface{})["text"].(map[string]interface{})["content"].(string); len(text) > 2000 {
+				s.t.Errorf("appended text of %d characters", len(text))
+			}
+		}
+		if children, ok := content["children"].([]interface{}); ok {
+			nested := make([]map[string]interface{}, len(children))
+			for i, c := range children {
+				nested[i] = c.(map[string]interface{})
+			}
+			delete(content, "children")
+			b["has_children"] = true
+			s.store(id, nested, depth+1)
+		}
+		s.children[parent] = append(s.children[parent], b)
+	}
+}
+
+// tree describes the stored children of id as "text(children)"
+func (s *blockStore) tree(id string) string {
+	var parts []string
+	for _, b := range s.children[id] {
+		var text string
+		for _, rt := range b[b["type"].(string)].(map[string]interface{})["text"].([]interface{}) {
+			text += rt.(map[string]interface{})["text"].(map[string]interface{})["content"].(string)
+		}
+		if children := s.tree(b["id"].(string)); children != "" {
+			text += "(" + children + ")"
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestBlockClient_AppendAll(t *testing.T) {
+	paragraph := func(text string) notionapi.Block {
+		b := &notionapi.ParagraphBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeParagraph}
+		b.Paragraph.Text = notionapi.Paragraph{{Type: notionapi.ObjectTypeText, Text: notionapi.Text{Content: text}}}
+		return b
+	}
+	bullet := func(text string, children ...notionapi.Block) notionapi.Block {
+		b := &notionapi.BulletedListItemBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeBulletedListItem}
+		b.BulletedListItem.Text = notionapi.Paragraph{{Type: notionapi.ObjectTypeText, Text: notionapi.Text{Content: text}}}
+		b.BulletedListItem.Children = children
+		return b
+	}
+
+	var children, wide []notionapi.Block
+	var want, wantWide []string
+	deep := bullet("l1", bullet("l2", bullet("l3", bullet("l4"))))
+	children = append(children, deep)
+	want = append(want, "l1(l2(l3(l4)))")
+	for i := 0; i < 120; i++ {
+		wide = append(wide, paragraph(fmt.Sprint("w", i)))
+		wantWide = append(wantWide, fmt.Sprint("w", i))
+	}
+	children = append(children, bullet("wide", wide...))
+	want = append(want, "wide("+strings.Join(wantWide, " ")+")")
+	long := strings.Repeat("word ", 900)
+	children = append(children, paragraph(long))
+	want = append(want, long)
+	for i := 0; i < 150; i++ {
+		children = append(children, paragraph(fmt.Sprint("p", i)))
+		want = append(want, fmt.Sprint("p", i))
+	}
+
+	store := &blockStore{t: t, children: make(map[string][]map[string]interface{})}
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(newTestClient(store.roundTrip)))
+	var appended, total int
+	err := client.Block.AppendAll(context.Background(), "page_id", children, &notionapi.AppendAllOptions{
+		Progress: func(a, t int) {
+			appended, total = a, t
+		},
+	})
+	if err != nil {
+		t.Fatalf("AppendAll() error = %v", err)
+	}
+
+	if got := store.tree("page_id"); got != strings.Join(want, " ") {
+		t.Errorf("AppendAll() appended %s", got)
+	}
+	if appended != 276 || total != 276 {
+		t.Errorf("AppendAll() reported %d of %d blocks, want 276", appended, total)
+	}
+	if n := len(store.children["page_id"][2]["paragraph"].(map[string]interface{})["text"].([]interface{})); n != 3 {
+		t.Errorf("AppendAll() split long text into %d parts, want 3", n)
+	}
+	if len(deep.(*notionapi.BulletedListItemBlock).BulletedListItem.Children) != 1 {
+		t.Errorf("AppendAll() modified the children")
+	}
+}
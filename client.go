@@ -31,6 +31,12 @@ type Client struct {
 	baseUrl       *url.URL
 	apiVersion    string
 	notionVersion string
+	retry         *RetryPolicy
+	limiter       *RateLimiter
+	strict        bool
+	validate      bool
+	middlewares   []Middleware
+	doer          Doer
 
 	Token Token
 
@@ -63,6 +69,7 @@ func NewClient(token Token, opts ...ClientOption) *Client {
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.doer = chainMiddlewares(append(c.middlewares, c.builtinMiddlewares()...), DoerFunc(c.send))
 
 	return c
 }
@@ -81,29 +88,89 @@ func WithVersion(version string) ClientOption {
 	}
 }
 
-func (c *Client) request(ctx context.Context, method string, urlStr string, queryParams map[string]string, requestBody interface{}) (*http.Response, error) {
-	u, err := c.baseUrl.Parse(fmt.Sprintf("%s/%s", c.apiVersion, urlStr))
+// call runs the call through the middleware chain and returns the decoded response
+func (c *Client) call(ctx context.Context, call *Call) (interface{}, error) {
+	return c.doer.Do(ctx, call)
+}
+
+// builtinMiddlewares returns the middlewares enabled by options. They run
+// inside the middlewares added by WithMiddleware, which therefore see
+// validation errors and the final result of retried calls. Every retry takes
+// a token from the rate limiter.
+func (c *Client) builtinMiddlewares() []Middleware {
+	var middlewares []Middleware
+	if c.validate {
+		middlewares = append(middlewares, validationMiddleware)
+	}
+	if c.retry != nil {
+		middlewares = append(middlewares, retryMiddleware(c.retry))
+	}
+	if c.limiter != nil {
+		middlewares = append(middlewares, rateLimitMiddleware(c.limiter))
+	}
+	return middlewares
+}
+
+// send is the innermost Doer. It performs a single request and decodes the
+// response.
+func (c *Client) send(ctx context.Context, call *Call) (interface{}, error) {
+	res, err := c.request(ctx, call)
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
 
-	var buf io.ReadWriter
-	if requestBody != nil {
-		body, err := json.Marshal(requestBody)
+	if call.decode == nil {
+		return nil, nil
+	}
+	v, err := call.decode(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if c.strict {
+		if err := checkSupported(v); err != nil {
+			return nil, err
+		}
+		if err := checkRichText(v); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func (c *Client) request(ctx context.Context, call *Call) (*http.Response, error) {
+	u, err := c.baseUrl.Parse(fmt.Sprintf("%s/%s", c.apiVersion, call.Path))
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if call.Body != nil {
+		body, err = json.Marshal(call.Body)
 		if err != nil {
 			return nil, err
 		}
-		buf = bytes.NewBuffer(body)
 	}
 
-	if len(queryParams) > 0 {
+	if len(call.Query) > 0 {
 		q := u.Query()
-		for k, v := range queryParams {
+		for k, v := range call.Query {
 			q.Add(k, v)
 		}
 		u.RawQuery = q.Encode()
 	}
-	req, err := http.NewRequest(method, u.String(), buf)
+
+	return c.do(ctx, call.Method, u.String(), body, call.Header)
+}
+
+// do performs a single HTTP round trip. API errors are returned as *Error,
+// transport errors as *url.Error.
+func (c *Client) do(ctx context.Context, method string, urlStr string, body []byte, header http.Header) (*http.Response, error) {
+	var buf io.Reader
+	if body != nil {
+		buf = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, urlStr, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -111,6 +178,12 @@ func (c *Client) request(ctx context.Context, method string, urlStr string, quer
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.Token.String()))
 	req.Header.Add("Notion-Version", c.notionVersion)
 	req.Header.Add("Content-Type", "application/json")
+	for k, values := range header {
+		req.Header.Del(k)
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
 
 	res, err := c.httpClient.Do(req.WithContext(ctx))
 	if err != nil {
@@ -118,13 +191,15 @@ func (c *Client) request(ctx context.Context, method string, urlStr string, quer
 	}
 
 	if res.StatusCode != http.StatusOK {
-		var apiErr Error
-		err = json.NewDecoder(res.Body).Decode(&apiErr)
-		if err != nil {
-			return nil, err
+		defer res.Body.Close()
+		apiErr := &Error{}
+		if err := json.NewDecoder(res.Body).Decode(apiErr); err != nil {
+			// e.g. an HTML page of a proxy
+			apiErr = &Error{Message: res.Status}
 		}
-
-		return nil, &apiErr
+		apiErr.Status = res.StatusCode
+		apiErr.retryAfter = parseRetryAfter(res.Header)
+		return nil, apiErr
 	}
 
 	return res, nil
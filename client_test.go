@@ -1,9 +1,15 @@
 package notionapi_test
 
 import (
+	"context"
+	"errors"
+	"github.com/jomei/notionapi"
 	"net/http"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 // RoundTripFunc .
@@ -24,16 +30,459 @@ func newTestClient(fn RoundTripFunc) *http.Client {
 // newMockedClient returns *http.Client which responds with content from given file
 func newMockedClient(t *testing.T, requestMockFile string, statusCode int) *http.Client {
 	return newTestClient(func(req *http.Request) *http.Response {
-		b, err := os.Open(requestMockFile)
-		if err != nil {
-			t.Fatal(err)
-		}
+		return newMockedClientResponse(t, requestMockFile, statusCode)
+	})
+}
+
+// newMockedClientResponse returns *http.Response with content from given file
+func newMockedClientResponse(t *testing.T, requestMockFile string, statusCode int) *http.Response {
+	b, err := os.Open(requestMockFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       b,
+		Header:     make(http.Header),
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	policy := notionapi.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name         string
+		statusCodes  []int
+		retryAfter   string
+		method       func(*notionapi.Client) error
+		wantAttempts int
+		wantErr      bool
+		wantRetryErr bool
+	}{
+		{
+			name:        "retries rate limited requests until they succeed",
+			statusCodes: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK},
+			method: func(c *notionapi.Client) error {
+				_, err := c.User.Get(context.Background(), "some_id")
+				return err
+			},
+			wantAttempts: 3,
+		},
+		{
+			name:        "gives up after max attempts",
+			statusCodes: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests},
+			method: func(c *notionapi.Client) error {
+				_, err := c.User.Get(context.Background(), "some_id")
+				return err
+			},
+			wantAttempts: 3,
+			wantErr:      true,
+			wantRetryErr: true,
+		},
+		{
+			name:        "does not retry client errors",
+			statusCodes: []int{http.StatusBadRequest},
+			method: func(c *notionapi.Client) error {
+				_, err := c.User.Get(context.Background(), "some_id")
+				return err
+			},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:        "does not retry non idempotent methods",
+			statusCodes: []int{http.StatusTooManyRequests},
+			method: func(c *notionapi.Client) error {
+				_, err := c.Page.Create(context.Background(), &notionapi.PageCreateRequest{})
+				return err
+			},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:        "ignores absurd retry-after values",
+			statusCodes: []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:  "99999999999",
+			method: func(c *notionapi.Client) error {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				_, err := c.User.Get(ctx, "some_id")
+				return err
+			},
+			wantAttempts: 2,
+		},
+		{
+			name:        "stops when retry-after exceeds the context deadline",
+			statusCodes: []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:  "60",
+			method: func(c *notionapi.Client) error {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				_, err := c.User.Get(ctx, "some_id")
+				return err
+			},
+			wantAttempts: 1,
+			wantErr:      true,
+			wantRetryErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			c := newTestClient(func(req *http.Request) *http.Response {
+				status := tt.statusCodes[attempts]
+				attempts++
+				file := "testdata/user_get.json"
+				switch {
+				case status == http.StatusTooManyRequests:
+					file = "testdata/rate_limited.json"
+				case status != http.StatusOK:
+					file = "testdata/validation_error.json"
+				}
+				b, err := os.Open(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				header := make(http.Header)
+				if tt.retryAfter != "" {
+					header.Set("Retry-After", tt.retryAfter)
+				}
+				return &http.Response{StatusCode: status, Body: b, Header: header}
+			})
+			client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c), notionapi.WithRetry(policy))
+
+			err := tt.method(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("request error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts got = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var apiErr *notionapi.Error
+			if !errors.As(err, &apiErr) {
+				t.Errorf("error %v does not wrap *notionapi.Error", err)
+			}
+			var retryErr *notionapi.RetryError
+			if errors.As(err, &retryErr) != tt.wantRetryErr {
+				t.Errorf("error %v, want RetryError: %v", err, tt.wantRetryErr)
+			}
+			if retryErr != nil && retryErr.Attempts != tt.wantAttempts {
+				t.Errorf("RetryError.Attempts got = %d, want %d", retryErr.Attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClient_Middleware(t *testing.T) {
+	type record struct {
+		operation string
+		objectID  string
+		body      interface{}
+		response  interface{}
+		err       error
+	}
+
+	tests := []struct {
+		name       string
+		filePath   string
+		statusCode int
+		method     func(*notionapi.Client) error
+		want       record
+		wantErr    bool
+	}{
+		{
+			name:       "sees operation, request body and decoded response",
+			filePath:   "testdata/database_query.json",
+			statusCode: http.StatusOK,
+			method: func(c *notionapi.Client) error {
+				_, err := c.Database.Query(context.Background(), "some_id", &notionapi.DatabaseQueryRequest{PageSize: 10})
+				return err
+			},
+			want: record{
+				operation: "databases.query",
+				objectID:  "some_id",
+				body:      &notionapi.DatabaseQueryRequest{PageSize: 10},
+				response:  &notionapi.DatabaseQueryResponse{},
+			},
+		},
+		{
+			name:       "sees api errors",
+			filePath:   "testdata/validation_error.json",
+			statusCode: http.StatusBadRequest,
+			method: func(c *notionapi.Client) error {
+				_, err := c.Page.Get(context.Background(), "some_id")
+				return err
+			},
+			want: record{
+				operation: "pages.get",
+				objectID:  "some_id",
+				err:       &notionapi.Error{},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got record
+			var order []string
+			var header string
+			c := newTestClient(func(req *http.Request) *http.Response {
+				header = req.Header.Get("X-Request-Id")
+				b, err := os.Open(tt.filePath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return &http.Response{StatusCode: tt.statusCode, Body: b, Header: make(http.Header)}
+			})
+			recorder := func(next notionapi.Doer) notionapi.Doer {
+				return notionapi.DoerFunc(func(ctx context.Context, call *notionapi.Call) (interface{}, error) {
+					order = append(order, "recorder")
+					got.operation = call.Operation
+					got.objectID = call.ObjectID
+					got.body = call.Body
+					got.response, got.err = next.Do(ctx, call)
+					return got.response, got.err
+				})
+			}
+			headers := func(next notionapi.Doer) notionapi.Doer {
+				return notionapi.DoerFunc(func(ctx context.Context, call *notionapi.Call) (interface{}, error) {
+					order = append(order, "headers")
+					call.Header = http.Header{"X-Request-Id": []string{"some_request"}}
+					return next.Do(ctx, call)
+				})
+			}
+			client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c), notionapi.WithMiddleware(recorder, headers))
 
-		resp := &http.Response{
-			StatusCode: statusCode,
-			Body:       b,
-			Header:     make(http.Header),
+			err := tt.method(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("request error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(order, []string{"recorder", "headers"}) {
+				t.Errorf("middleware order got = %v", order)
+			}
+			if header != "some_request" {
+				t.Errorf("header set by middleware got = %q", header)
+			}
+			if got.operation != tt.want.operation || got.objectID != tt.want.objectID {
+				t.Errorf("call got = %s %s, want %s %s", got.operation, got.objectID, tt.want.operation, tt.want.objectID)
+			}
+			if !reflect.DeepEqual(got.body, tt.want.body) {
+				t.Errorf("body got = %v, want %v", got.body, tt.want.body)
+			}
+			if reflect.TypeOf(got.response) != reflect.TypeOf(tt.want.response) {
+				t.Errorf("response got = %T, want %T", got.response, tt.want.response)
+			}
+			if reflect.TypeOf(got.err) != reflect.TypeOf(tt.want.err) {
+				t.Errorf("error got = %T, want %T", got.err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestClient_Validation(t *testing.T) {
+	paragraph := func(text string, children ...notionapi.Block) notionapi.Block {
+		b := &notionapi.ParagraphBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeParagraph}
+		b.Paragraph.Text = notionapi.Paragraph{{Text: notionapi.Text{Content: text}}}
+		b.Paragraph.Children = children
+		return b
+	}
+	long := strings.Repeat("a", 2001)
+	many := make([]notionapi.Block, 101)
+	for i := range many {
+		many[i] = paragraph("text")
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		method   func(*notionapi.Client) error
+		want     []notionapi.FieldError
+	}{
+		{
+			name:     "valid page",
+			filePath: "testdata/page_create.json",
+			method: func(c *notionapi.Client) error {
+				_, err := c.Page.Create(context.Background(), &notionapi.PageCreateRequest{
+					Parent:   notionapi.Parent{PageID: "some_id"},
+					Children: []notionapi.Block{paragraph("text", paragraph("nested"))},
+				})
+				return err
+			},
+		},
+		{
+			name: "page without parent",
+			method: func(c *notionapi.Client) error {
+				_, err := c.Page.Create(context.Background(), &notionapi.PageCreateRequest{
+					Properties: notionapi.Properties{
+						"Name": notionapi.TitleProperty{Title: notionapi.Paragraph{{Text: notionapi.Text{Content: long}}}},
+					},
+				})
+				return err
+			},
+			want: []notionapi.FieldError{
+				{Path: "parent", Message: "page_id or database_id is required"},
+				{Path: "properties.Name.title[0].text.content", Message: "at most 2000 characters are allowed, got 2001"},
+			},
+		},
+		{
+			name: "page with two parents",
+			method: func(c *notionapi.Client) error {
+				_, err := c.Page.Create(context.Background(), &notionapi.PageCreateRequest{
+					Parent:   notionapi.Parent{PageID: "some_id", DatabaseID: "some_id"},
+					Children: []notionapi.Block{paragraph("1", paragraph("2", paragraph("3")))},
+				})
+				return err
+			},
+			want: []notionapi.FieldError{
+				{Path: "parent", Message: "only one of page_id and database_id can be set"},
+			},
+		},
+		{
+			name: "append deep children",
+			method: func(c *notionapi.Client) error {
+				_, err := c.Block.AppendChildren(context.Background(), "some_id", &notionapi.AppendBlockChildrenRequest{
+					Children: []notionapi.Block{paragraph("1", paragraph("2", paragraph("3")))},
+				})
+				return err
+			},
+			want: []notionapi.FieldError{
+				{Path: "children[0].paragraph.children[0].paragraph.children", Message: "blocks can be nested at most 2 levels deep in one request"},
+			},
+		},
+		{
+			name: "append too many children",
+			method: func(c *notionapi.Client) error {
+				_, err := c.Block.AppendChildren(context.Background(), "some_id", &notionapi.AppendBlockChildrenRequest{
+					Children: append(many, paragraph(long), nil),
+				})
+				return err
+			},
+			want: []notionapi.FieldError{
+				{Path: "children", Message: "at most 100 children are allowed, got 103"},
+				{Path: "children[101].paragraph.text[0].text.content", Message: "at most 2000 characters are allowed, got 2001"},
+				{Path: "children[102]", Message: "missing block"},
+			},
+		},
+		{
+			name: "query with invalid filter",
+			method: func(c *notionapi.Client) error {
+				_, err := c.Database.Query(context.Background(), "some_id", &notionapi.DatabaseQueryRequest{
+					PageSize: 101,
+					Filter: notionapi.AndCompoundFilter{
+						&notionapi.PropertyFilter{Property: "Done", Checkbox: &notionapi.CheckboxFilterCondition{Equals: true}},
+						notionapi.OrCompoundFilter{},
+						&notionapi.PropertyFilter{Property: "Name"},
+					},
+				})
+				return err
+			},
+			want: []notionapi.FieldError{
+				{Path: "page_size", Message: "must be between 1 and 100, got 101"},
+				{Path: "filter.and[1].or", Message: "empty filter"},
+				{Path: "filter.and[2]", Message: `filter on "Name" needs exactly one condition, got 0`},
+			},
+		},
+		{
+			name: "search with invalid filter",
+			method: func(c *notionapi.Client) error {
+				_, err := c.Search.Do(context.Background(), &notionapi.SearchRequest{
+					PageSize: -1,
+					Filter:   map[string]string{"property": "object", "value": "block"},
+				})
+				return err
+			},
+			want: []notionapi.FieldError{
+				{Path: "page_size", Message: "must be between 1 and 100, got -1"},
+				{Path: "filter.value", Message: `must be page or database, got "block"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(func(req *http.Request) *http.Response {
+				if tt.want != nil {
+					t.Errorf("sent invalid request to %s", req.URL.Path)
+				}
+				return newMockedClientResponse(t, tt.filePath, http.StatusOK)
+			})
+			client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c), notionapi.WithValidation())
+			err := tt.method(client)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var validationErr *notionapi.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("got error %v, want *ValidationError", err)
+			}
+			if !reflect.DeepEqual(validationErr.Fields, tt.want) {
+				t.Errorf("got fields %+v, want %+v", validationErr.Fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_MiddlewareAroundRetries(t *testing.T) {
+	attempts := 0
+	c := newTestClient(func(req *http.Request) *http.Response {
+		attempts++
+		if attempts == 1 {
+			return newMockedClientResponse(t, "testdata/rate_limited.json", http.StatusTooManyRequests)
 		}
-		return resp
+		return newMockedClientResponse(t, "testdata/user_get.json", http.StatusOK)
 	})
+	calls := 0
+	counter := func(next notionapi.Doer) notionapi.Doer {
+		return notionapi.DoerFunc(func(ctx context.Context, call *notionapi.Call) (interface{}, error) {
+			calls++
+			return next.Do(ctx, call)
+		})
+	}
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c),
+		notionapi.WithRetry(notionapi.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}),
+		notionapi.WithRateLimit(1000, 1),
+		notionapi.WithMiddleware(counter))
+
+	if _, err := client.User.Get(context.Background(), "some_id"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 || attempts != 2 {
+		t.Errorf("middleware saw %d calls for %d attempts, want 1 and 2", calls, attempts)
+	}
+}
+
+func TestClient_MiddlewareSeesValidationErrors(t *testing.T) {
+	c := newTestClient(func(req *http.Request) *http.Response {
+		t.Errorf("sent invalid request to %s", req.URL.Path)
+		return newMockedClientResponse(t, "testdata/page_create.json", http.StatusOK)
+	})
+	var got error
+	recorder := func(next notionapi.Doer) notionapi.Doer {
+		return notionapi.DoerFunc(func(ctx context.Context, call *notionapi.Call) (interface{}, error) {
+			v, err := next.Do(ctx, call)
+			got = err
+			return v, err
+		})
+	}
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c),
+		notionapi.WithValidation(), notionapi.WithMiddleware(recorder))
+
+	_, err := client.Page.Create(context.Background(), &notionapi.PageCreateRequest{})
+	var validationErr *notionapi.ValidationError
+	if !errors.As(got, &validationErr) || got != err {
+		t.Errorf("middleware saw error %v, want the *ValidationError %v", got, err)
+	}
 }
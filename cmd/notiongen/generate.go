@@ -0,0 +1,314 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jomei/notionapi"
+)
+
+// Options configures the generated code
+type Options struct {
+	// Package is the name of the generated package
+	Package string
+	// TypeName of the row struct. It defaults to the database title.
+	TypeName string
+}
+
+// field is a database property mapped to a struct field
+type field struct {
+	Name     string
+	Property string
+	Type     notionapi.PropertyType
+	// GoType is the type of the struct field
+	GoType string
+	// OptionType is the named string type of select and multi_select options
+	OptionType string
+	Options    []option
+	// Condition is the name of the filter condition type, e.g. "Text" for
+	// notionapi.TextFilterCondition. It is empty for properties that can not
+	// be filtered.
+	Condition string
+}
+
+type option struct {
+	Const string
+	Name  string
+}
+
+// reservedNames are the methods generated on the row type
+var reservedNames = map[string]bool{"UnmarshalPage": true, "MarshalProperties": true}
+
+// Generate returns the formatted Go source of a row struct, option constants
+// and a typed query builder for db.
+func Generate(db *notionapi.Database, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "models"
+	}
+	typeName := opts.TypeName
+	if typeName == "" {
+		typeName = identifier(db.Title.PlainText())
+	}
+	if typeName == "" {
+		return nil, fmt.Errorf("notiongen: database %s has no title, set a type name", db.ID)
+	}
+
+	fields, skipped := fields(typeName, db.Properties)
+	g := &generator{typeName: typeName, title: db.Title.PlainText(), fields: fields}
+
+	g.printf("// Code generated by notiongen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", opts.Package)
+	g.printf("import (\n\t\"context\"\n")
+	if g.needsTime() {
+		g.printf("\t\"time\"\n")
+	}
+	g.printf("\n\t\"github.com/jomei/notionapi\"\n)\n\n")
+
+	g.printf("// %sID is the ID of the %q database\n", typeName, g.title)
+	g.printf("const %sID notionapi.DatabaseID = %q\n\n", typeName, db.ID)
+	g.rowType(skipped)
+	g.optionTypes()
+	g.query()
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("notiongen: format generated code: %w", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	buf      bytes.Buffer
+	typeName string
+	title    string
+	fields   []field
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) needsTime() bool {
+	for _, f := range g.fields {
+		if f.GoType == "time.Time" {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) rowType(skipped []string) {
+	g.printf("// %s is a row of the %q database\n", g.typeName, g.title)
+	g.printf("type %s struct {\n", g.typeName)
+	g.printf("\tID notionapi.ObjectID `notion:\"-\"`\n")
+	for _, f := range g.fields {
+		g.printf("\t%s %s `notion:\"%s,%s\"`\n", f.Name, f.GoType, f.Property, f.Type)
+	}
+	for _, s := range skipped {
+		g.printf("\t// %s\n", s)
+	}
+	g.printf("}\n\n")
+
+	g.printf("// UnmarshalPage reads the properties of page into r\n")
+	g.printf("func (r *%s) UnmarshalPage(page *notionapi.Page) error {\n", g.typeName)
+	g.printf("\tr.ID = page.ID\n")
+	g.printf("\treturn notionapi.UnmarshalProperties(page.Properties, r)\n}\n\n")
+
+	g.printf("// MarshalProperties returns the page properties of r\n")
+	g.printf("func (r %s) MarshalProperties() (notionapi.Properties, error) {\n", g.typeName)
+	g.printf("\treturn notionapi.MarshalProperties(r)\n}\n\n")
+}
+
+func (g *generator) optionTypes() {
+	for _, f := range g.fields {
+		if f.OptionType == "" {
+			continue
+		}
+		g.printf("// %s is an option of the %q property\n", f.OptionType, f.Property)
+		g.printf("type %s string\n\n", f.OptionType)
+		if len(f.Options) == 0 {
+			continue
+		}
+		g.printf("const (\n")
+		for _, o := range f.Options {
+			g.printf("\t%s %s = %q\n", o.Const, f.OptionType, o.Name)
+		}
+		g.printf(")\n\n")
+	}
+}
+
+func (g *generator) query() {
+	q := g.typeName + "Query"
+	g.printf("// %s builds a query of the %q database. Filters are combined with and.\n", q, g.title)
+	g.printf("type %s struct {\n\tfilters []notionapi.Filter\n\tsorts []notionapi.SortObject\n}\n\n", q)
+
+	g.printf("// New%s returns an empty query\n", q)
+	g.printf("func New%s() *%s {\n\treturn &%s{}\n}\n\n", q, q, q)
+
+	for _, f := range g.fields {
+		if f.Condition == "" {
+			continue
+		}
+		g.printf("// Where%s filters by the %q property\n", f.Name, f.Property)
+		g.printf("func (q *%s) Where%s(condition notionapi.%sFilterCondition) *%s {\n", q, f.Name, f.Condition, q)
+		g.printf("\tq.filters = append(q.filters, &notionapi.PropertyFilter{Property: %q, %s: &condition})\n", f.Property, f.Condition)
+		g.printf("\treturn q\n}\n\n")
+
+		switch f.Type {
+		case notionapi.PropertyTypeSelect:
+			g.printf("// %sIs filters by the selected option of %q\n", f.Name, f.Property)
+			g.printf("func (q *%s) %sIs(option %s) *%s {\n", q, f.Name, f.OptionType, q)
+			g.printf("\treturn q.Where%s(notionapi.SelectFilterCondition{Equals: string(option)})\n}\n\n", f.Name)
+		case notionapi.PropertyTypeMultiSelect:
+			g.printf("// %sContains filters by an option of %q\n", f.Name, f.Property)
+			g.printf("func (q *%s) %sContains(option %s) *%s {\n", q, f.Name, f.OptionType, q)
+			g.printf("\treturn q.Where%s(notionapi.MultiSelectFilterCondition{Contains: string(option)})\n}\n\n", f.Name)
+		}
+
+		g.printf("// SortBy%s orders the results by the %q property\n", f.Name, f.Property)
+		g.printf("func (q *%s) SortBy%s(direction notionapi.SortOrder) *%s {\n", q, f.Name, q)
+		g.printf("\tq.sorts = append(q.sorts, notionapi.SortObject{Property: %q, Direction: direction})\n", f.Property)
+		g.printf("\treturn q\n}\n\n")
+	}
+
+	g.printf("// Request returns the query request\n")
+	g.printf("func (q *%s) Request() *notionapi.DatabaseQueryRequest {\n", q)
+	g.printf("\trequest := &notionapi.DatabaseQueryRequest{Sorts: q.sorts}\n")
+	g.printf("\tswitch len(q.filters) {\n\tcase 0:\n\tcase 1:\n\t\trequest.Filter = q.filters[0]\n")
+	g.printf("\tdefault:\n\t\trequest.Filter = notionapi.AndCompoundFilter(q.filters)\n\t}\n")
+	g.printf("\treturn request\n}\n\n")
+
+	g.printf("// All returns all rows matching the query\n")
+	g.printf("func (q *%s) All(ctx context.Context, databases notionapi.DatabaseService) ([]%s, error) {\n", q, g.typeName)
+	g.printf("\tpages, err := databases.QueryAll(ctx, %sID, q.Request()).Collect(0)\n", g.typeName)
+	g.printf("\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	g.printf("\trows := make([]%s, len(pages))\n", g.typeName)
+	g.printf("\tfor i := range pages {\n\t\tif err := rows[i].UnmarshalPage(&pages[i]); err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t}\n")
+	g.printf("\treturn rows, nil\n}\n")
+}
+
+// fields maps the properties of a database to struct fields ordered by name
+// with the title first. It also returns notes on skipped properties.
+func fields(typeName string, properties notionapi.PropertyConfigs) ([]field, []string) {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ti := properties[names[i]].GetType() == notionapi.PropertyTypeTitle
+		tj := properties[names[j]].GetType() == notionapi.PropertyTypeTitle
+		if ti != tj {
+			return ti
+		}
+		return names[i] < names[j]
+	})
+
+	used := map[string]bool{"ID": true}
+	for name := range reservedNames {
+		used[name] = true
+	}
+	var result []field
+	var skipped []string
+	for _, name := range names {
+		p := properties[name]
+		f := field{Property: name, Type: p.GetType()}
+		f.GoType, f.Condition = goType(f.Type)
+		if f.GoType == "" {
+			skipped = append(skipped, fmt.Sprintf("%q: %s properties are not supported", name, f.Type))
+			continue
+		}
+		f.Name = unique(identifier(name), used)
+
+		var options []notionapi.Option
+		switch p := p.(type) {
+		case *notionapi.SelectPropertyConfig:
+			options = p.Select.Options
+		case *notionapi.MultiSelectPropertyConfig:
+			options = p.MultiSelect.Options
+		}
+		switch f.Type {
+		case notionapi.PropertyTypeSelect:
+			f.OptionType = typeName + f.Name
+			f.GoType = f.OptionType
+		case notionapi.PropertyTypeMultiSelect:
+			f.OptionType = typeName + f.Name
+			f.GoType = "[]" + f.OptionType
+		}
+		constants := map[string]bool{}
+		for _, o := range options {
+			f.Options = append(f.Options, option{
+				Const: unique(f.OptionType+identifier(o.Name), constants),
+				Name:  o.Name,
+			})
+		}
+		result = append(result, f)
+	}
+	return result, skipped
+}
+
+// goType returns the field type and the filter condition of a property type
+func goType(t notionapi.PropertyType) (string, string) {
+	switch t {
+	case notionapi.PropertyTypeTitle, notionapi.PropertyTypeRichText, notionapi.PropertyTypeURL,
+		notionapi.PropertyTypeEmail, notionapi.PropertyTypePhoneNumber:
+		return "string", "Text"
+	case notionapi.PropertyTypeNumber:
+		return "*float64", "Number"
+	case notionapi.PropertyTypeCheckbox:
+		return "bool", "Checkbox"
+	case notionapi.PropertyTypeSelect:
+		return "string", "Select"
+	case notionapi.PropertyTypeMultiSelect:
+		return "[]string", "MultiSelect"
+	case notionapi.PropertyTypeDate:
+		return "time.Time", "Date"
+	case notionapi.PropertyTypeCreatedTime, notionapi.PropertyTypeLastEditedTime:
+		return "time.Time", ""
+	case notionapi.PropertyTypePeople:
+		return "[]notionapi.UserID", "People"
+	case notionapi.PropertyTypeCreatedBy, notionapi.PropertyTypeLastEditedBy:
+		return "notionapi.UserID", ""
+	case notionapi.PropertyTypeRelation:
+		return "[]notionapi.PageID", "Relation"
+	}
+	return "", ""
+}
+
+// identifier turns s into an exported Go identifier, e.g. "due date" into
+// "DueDate"
+func identifier(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteString("X")
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// unique returns name, or name with a numeric suffix if it is already used
+func unique(name string, used map[string]bool) string {
+	if name == "" {
+		name = "Property"
+	}
+	result := name
+	for i := 2; used[result]; i++ {
+		result = fmt.Sprintf("%s%d", name, i)
+	}
+	used[result] = true
+	return result
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/jomei/notionapi/cmd/notiongen/testdata/tasks"
+)
+
+var update = flag.Bool("update", false, "update the generated code in testdata")
+
+func TestGenerate(t *testing.T) {
+	t.Run("matches the generated package", func(t *testing.T) {
+		db, err := readDatabase("testdata/tasks.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Generate(db, Options{Package: "tasks", TypeName: "Task"})
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		golden := "testdata/tasks/tasks.go"
+		if *update {
+			if err := ioutil.WriteFile(golden, got, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("Generate() differs from %s, run go test -update\n%s", golden, got)
+		}
+	})
+
+	t.Run("names the type after the database", func(t *testing.T) {
+		db, err := readDatabase("../../testdata/database_get.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Generate(db, Options{})
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		for _, want := range []string{
+			"package models",
+			"type TestDatabase struct",
+			"SomeAnotherColumn []notionapi.UserID `notion:\"Some another column,people\"`",
+			"TestDatabaseTagsTag TestDatabaseTags = \"tag\"",
+			"func (q *TestDatabaseQuery) WhereSomeColumn(condition notionapi.TextFilterCondition) *TestDatabaseQuery",
+		} {
+			if !strings.Contains(string(got), want) {
+				t.Errorf("Generate() does not contain %q\n%s", want, got)
+			}
+		}
+	})
+
+	t.Run("needs a type name", func(t *testing.T) {
+		if _, err := Generate(&notionapi.Database{ID: "some_id"}, Options{}); err == nil {
+			t.Error("Generate() error = nil, want error")
+		}
+	})
+}
+
+func TestGeneratedCode(t *testing.T) {
+	t.Run("unmarshals a page", func(t *testing.T) {
+		data, err := ioutil.ReadFile("../../testdata/page_get_row.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var page notionapi.Page
+		if err := json.Unmarshal(data, &page); err != nil {
+			t.Fatal(err)
+		}
+		var task tasks.Task
+		if err := task.UnmarshalPage(&page); err != nil {
+			t.Fatalf("UnmarshalPage() error = %v", err)
+		}
+		if task.ID != "some_id" || task.Name != "Write docs" || task.Status != tasks.TaskStatusInProgress ||
+			len(task.Tags) != 2 || task.Tags[0] != tasks.TaskTagsDocs {
+			t.Errorf("UnmarshalPage() got = %+v", task)
+		}
+	})
+
+	t.Run("builds a query", func(t *testing.T) {
+		q := tasks.NewTaskQuery().
+			StatusIs(tasks.TaskStatusDone).
+			TagsContains(tasks.TaskTagsDocs).
+			SortByDueDate(notionapi.SortOrderDESC)
+		got, err := json.Marshal(q.Request())
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"sorts":[{"property":"Due date","direction":"descending"}],"filter":{"and":[` +
+			`{"property":"Status","select":{"equals":"Done"}},` +
+			`{"property":"Tags","multi_select":{"contains":"docs"}}]}}`
+		if string(got) != want {
+			t.Errorf("Request() got = %s, want %s", got, want)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+// Command notiongen generates typed Go models from the schema of a Notion
+// database.
+//
+// For each database it writes a row struct with "notion" tags for
+// notionapi.UnmarshalProperties and notionapi.MarshalProperties, constants for
+// the options of select and multi_select properties and a query builder whose
+// filter methods only accept conditions valid for the property type. After a
+// schema change, regenerating the models turns code relying on removed or
+// retyped properties into compile errors.
+//
+// Usage:
+//
+//	NOTION_TOKEN=secret notiongen -db <database id> -package models -o tasks.go
+//	notiongen -schema database.json -type Task -o task.go
+//
+// The -schema flag reads a database object as returned by the API, e.g. a
+// saved response of Database.Get.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/jomei/notionapi"
+)
+
+func main() {
+	var (
+		databaseID = flag.String("db", "", "ID of the database, read with the token in NOTION_TOKEN")
+		schemaFile = flag.String("schema", "", "JSON file with a database object")
+		pkg        = flag.String("package", "models", "package name of the generated code")
+		typeName   = flag.String("type", "", "name of the row type, defaults to the database title")
+		output     = flag.String("o", "", "output file, defaults to stdout")
+	)
+	flag.Parse()
+
+	if err := run(*databaseID, *schemaFile, *output, Options{Package: *pkg, TypeName: *typeName}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(databaseID, schemaFile, output string, opts Options) error {
+	db, err := loadDatabase(databaseID, schemaFile)
+	if err != nil {
+		return err
+	}
+	src, err := Generate(db, opts)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(output, src, 0644)
+}
+
+func loadDatabase(databaseID, schemaFile string) (*notionapi.Database, error) {
+	switch {
+	case schemaFile != "" && databaseID != "":
+		return nil, fmt.Errorf("notiongen: -db and -schema are mutually exclusive")
+	case schemaFile != "":
+		return readDatabase(schemaFile)
+	case databaseID != "":
+		token := os.Getenv("NOTION_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("notiongen: NOTION_TOKEN is not set")
+		}
+		client := notionapi.NewClient(notionapi.Token(token))
+		return client.Database.Get(context.Background(), notionapi.DatabaseID(databaseID))
+	}
+	return nil, fmt.Errorf("notiongen: either -db or -schema is required")
+}
+
+func readDatabase(path string) (*notionapi.Database, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var db notionapi.Database
+	if err := json.Unmarshal(data, &db); err != nil {
+		return nil, fmt.Errorf("notiongen: read %s: %w", path, err)
+	}
+	return &db, nil
+}
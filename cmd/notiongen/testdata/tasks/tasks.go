@@ -0,0 +1,201 @@
+// Code generated by notiongen. DO NOT EDIT.
+
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// TaskID is the ID of the "Tasks" database
+const TaskID notionapi.DatabaseID = "some_id"
+
+// Task is a row of the "Tasks" database
+type Task struct {
+	ID          notionapi.ObjectID `notion:"-"`
+	Name        string             `notion:"Name,title"`
+	Created     time.Time          `notion:"Created,created_time"`
+	Done        bool               `notion:"Done,checkbox"`
+	DueDate     time.Time          `notion:"Due date,date"`
+	Estimate    *float64           `notion:"Estimate,number"`
+	Owners      []notionapi.UserID `notion:"Owners,people"`
+	ParentTasks []notionapi.PageID `notion:"Parent tasks,relation"`
+	Status      TaskStatus         `notion:"Status,select"`
+	Tags        []TaskTags         `notion:"Tags,multi_select"`
+	// "Score": formula properties are not supported
+}
+
+// UnmarshalPage reads the properties of page into r
+func (r *Task) UnmarshalPage(page *notionapi.Page) error {
+	r.ID = page.ID
+	return notionapi.UnmarshalProperties(page.Properties, r)
+}
+
+// MarshalProperties returns the page properties of r
+func (r Task) MarshalProperties() (notionapi.Properties, error) {
+	return notionapi.MarshalProperties(r)
+}
+
+// TaskStatus is an option of the "Status" property
+type TaskStatus string
+
+const (
+	TaskStatusNotStarted TaskStatus = "Not started"
+	TaskStatusInProgress TaskStatus = "In progress"
+	TaskStatusDone       TaskStatus = "Done"
+)
+
+// TaskTags is an option of the "Tags" property
+type TaskTags string
+
+const (
+	TaskTagsDocs         TaskTags = "docs"
+	TaskTagsX2021Roadmap TaskTags = "2021 roadmap"
+)
+
+// TaskQuery builds a query of the "Tasks" database. Filters are combined with and.
+type TaskQuery struct {
+	filters []notionapi.Filter
+	sorts   []notionapi.SortObject
+}
+
+// NewTaskQuery returns an empty query
+func NewTaskQuery() *TaskQuery {
+	return &TaskQuery{}
+}
+
+// WhereName filters by the "Name" property
+func (q *TaskQuery) WhereName(condition notionapi.TextFilterCondition) *TaskQuery {
+	q.filters = append(q.filters, &notionapi.PropertyFilter{Property: "Name", Text: &condition})
+	return q
+}
+
+// SortByName orders the results by the "Name" property
+func (q *TaskQuery) SortByName(direction notionapi.SortOrder) *TaskQuery {
+	q.sorts = append(q.sorts, notionapi.SortObject{Property: "Name", Direction: direction})
+	return q
+}
+
+// WhereDone filters by the "Done" property
+func (q *TaskQuery) WhereDone(condition notionapi.CheckboxFilterCondition) *TaskQuery {
+	q.filters = append(q.filters, &notionapi.PropertyFilter{Property: "Done", Checkbox: &condition})
+	return q
+}
+
+// SortByDone orders the results by the "Done" property
+func (q *TaskQuery) SortByDone(direction notionapi.SortOrder) *TaskQuery {
+	q.sorts = append(q.sorts, notionapi.SortObject{Property: "Done", Direction: direction})
+	return q
+}
+
+// WhereDueDate filters by the "Due date" property
+func (q *TaskQuery) WhereDueDate(condition notionapi.DateFilterCondition) *TaskQuery {
+	q.filters = append(q.filters, &notionapi.PropertyFilter{Property: "Due date", Date: &condition})
+	return q
+}
+
+// SortByDueDate orders the results by the "Due date" property
+func (q *TaskQuery) SortByDueDate(direction notionapi.SortOrder) *TaskQuery {
+	q.sorts = append(q.sorts, notionapi.SortObject{Property: "Due date", Direction: direction})
+	return q
+}
+
+// WhereEstimate filters by the "Estimate" property
+func (q *TaskQuery) WhereEstimate(condition notionapi.NumberFilterCondition) *TaskQuery {
+	q.filters = append(q.filters, &notionapi.PropertyFilter{Property: "Estimate", Number: &condition})
+	return q
+}
+
+// SortByEstimate orders the results by the "Estimate" property
+func (q *TaskQuery) SortByEstimate(direction notionapi.SortOrder) *TaskQuery {
+	q.sorts = append(q.sorts, notionapi.SortObject{Property: "Estimate", Direction: direction})
+	return q
+}
+
+// WhereOwners filters by the "Owners" property
+func (q *TaskQuery) WhereOwners(condition notionapi.PeopleFilterCondition) *TaskQuery {
+	q.filters = append(q.filters, &notionapi.PropertyFilter{Property: "Owners", People: &condition})
+	return q
+}
+
+// SortByOwners orders the results by the "Owners" property
+func (q *TaskQuery) SortByOwners(direction notionapi.SortOrder) *TaskQuery {
+	q.sorts = append(q.sorts, notionapi.SortObject{Property: "Owners", Direction: direction})
+	return q
+}
+
+// WhereParentTasks filters by the "Parent tasks" property
+func (q *TaskQuery) WhereParentTasks(condition notionapi.RelationFilterCondition) *TaskQuery {
+	q.filters = append(q.filters, &notionapi.PropertyFilter{Property: "Parent tasks", Relation: &condition})
+	return q
+}
+
+// SortByParentTasks orders the results by the "Parent tasks" property
+func (q *TaskQuery) SortByParentTasks(direction notionapi.SortOrder) *TaskQuery {
+	q.sorts = append(q.sorts, notionapi.SortObject{Property: "Parent tasks", Direction: direction})
+	return q
+}
+
+// WhereStatus filters by the "Status" property
+func (q *TaskQuery) WhereStatus(condition notionapi.SelectFilterCondition) *TaskQuery {
+	q.filters = append(q.filters, &notionapi.PropertyFilter{Property: "Status", Select: &condition})
+	return q
+}
+
+// StatusIs filters by the selected option of "Status"
+func (q *TaskQuery) StatusIs(option TaskStatus) *TaskQuery {
+	return q.WhereStatus(notionapi.SelectFilterCondition{Equals: string(option)})
+}
+
+// SortByStatus orders the results by the "Status" property
+func (q *TaskQuery) SortByStatus(direction notionapi.SortOrder) *TaskQuery {
+	q.sorts = append(q.sorts, notionapi.SortObject{Property: "Status", Direction: direction})
+	return q
+}
+
+// WhereTags filters by the "Tags" property
+func (q *TaskQuery) WhereTags(condition notionapi.MultiSelectFilterCondition) *TaskQuery {
+	q.filters = append(q.filters, &notionapi.PropertyFilter{Property: "Tags", MultiSelect: &condition})
+	return q
+}
+
+// TagsContains filters by an option of "Tags"
+func (q *TaskQuery) TagsContains(option TaskTags) *TaskQuery {
+	return q.WhereTags(notionapi.MultiSelectFilterCondition{Contains: string(option)})
+}
+
+// SortByTags orders the results by the "Tags" property
+func (q *TaskQuery) SortByTags(direction notionapi.SortOrder) *TaskQuery {
+	q.sorts = append(q.sorts, notionapi.SortObject{Property: "Tags", Direction: direction})
+	return q
+}
+
+// Request returns the query request
+func (q *TaskQuery) Request() *notionapi.DatabaseQueryRequest {
+	request := &notionapi.DatabaseQueryRequest{Sorts: q.sorts}
+	switch len(q.filters) {
+	case 0:
+	case 1:
+		request.Filter = q.filters[0]
+	default:
+		request.Filter = notionapi.AndCompoundFilter(q.filters)
+	}
+	return request
+}
+
+// All returns all rows matching the query
+func (q *TaskQuery) All(ctx context.Context, databases notionapi.DatabaseService) ([]Task, error) {
+	pages, err := databases.QueryAll(ctx, TaskID, q.Request()).Collect(0)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]Task, len(pages))
+	for i := range pages {
+		if err := rows[i].UnmarshalPage(&pages[i]); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
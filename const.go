@@ -6,6 +6,8 @@ const (
 	ObjectTypePage     ObjectType = "page"
 	ObjectTypeList     ObjectType = "list"
 	ObjectTypeText     ObjectType = "text"
+	ObjectTypeMention  ObjectType = "mention"
+	ObjectTypeEquation ObjectType = "equation"
 	ObjectTypeUser     ObjectType = "user"
 	ObjectTypeError    ObjectType = "error"
 )
@@ -19,7 +21,7 @@ const (
 	PropertyTypeCheckbox       PropertyType = "checkbox"
 	PropertyTypeEmail          PropertyType = "email"
 	PropertyTypeURL            PropertyType = "url"
-	PropertyTypeFile           PropertyType = "file"
+	PropertyTypeFiles          PropertyType = "files"
 	PropertyTypePhoneNumber    PropertyType = "phone_number"
 	PropertyTypeFormula        PropertyType = "formula"
 	PropertyTypeDate           PropertyType = "date"
@@ -30,6 +32,9 @@ const (
 	PropertyTypeCreatedBy      PropertyType = "created_by"
 	PropertyTypeLastEditedTime PropertyType = "last_edited_time"
 	PropertyTypeLastEditedBy   PropertyType = "last_edited_by"
+
+	// Deprecated: use PropertyTypeFiles
+	PropertyTypeFile = PropertyTypeFiles
 )
 
 const (
@@ -76,6 +81,16 @@ const (
 	ColorPurple  Color = "purple"
 	ColorPink    Color = "pink"
 	ColorRed     Color = "red"
+
+	ColorGrayBackground   Color = "gray_background"
+	ColorBrownBackground  Color = "brown_background"
+	ColorOrangeBackground Color = "orange_background"
+	ColorYellowBackground Color = "yellow_background"
+	ColorGreenBackground  Color = "green_background"
+	ColorBlueBackground   Color = "blue_background"
+	ColorPurpleBackground Color = "purple_background"
+	ColorPinkBackground   Color = "pink_background"
+	ColorRedBackground    Color = "red_background"
 )
 
 const (
@@ -99,6 +114,27 @@ const (
 	FunctionRange             FunctionType = "range"
 )
 
+const (
+	MentionTypeUser        MentionType = "user"
+	MentionTypePage        MentionType = "page"
+	MentionTypeDatabase    MentionType = "database"
+	MentionTypeDate        MentionType = "date"
+	MentionTypeLinkPreview MentionType = "link_preview"
+)
+
+const (
+	FormulaTypeString  FormulaType = "string"
+	FormulaTypeNumber  FormulaType = "number"
+	FormulaTypeBoolean FormulaType = "boolean"
+	FormulaTypeDate    FormulaType = "date"
+)
+
+const (
+	RollupTypeNumber RollupType = "number"
+	RollupTypeDate   RollupType = "date"
+	RollupTypeArray  RollupType = "array"
+)
+
 const (
 	ConditionEquals         Condition = "equals"
 	ConditionDoesNotEqual   Condition = "does_not_equal"
@@ -107,11 +143,12 @@ const (
 	ConditionDoesStartsWith Condition = "starts_with"
 	ConditionDoesEndsWith   Condition = "ends_with"
 	ConditionDoesIsEmpty    Condition = "is_empty"
+	ConditionIsNotEmpty     Condition = "is_not_empty"
 	ConditionGreaterThan    Condition = "greater_than"
 	ConditionLessThan       Condition = "less_than"
 
 	ConditionGreaterThanOrEqualTo Condition = "greater_than_or_equal_to"
-	ConditionLessThanOrEqualTo    Condition = "greater_than_or_equal_to"
+	ConditionLessThanOrEqualTo    Condition = "less_than_or_equal_to"
 
 	ConditionBefore     Condition = "before"
 	ConditionAfter      Condition = "after"
@@ -163,4 +200,47 @@ const (
 	BlockTypeToggle      BlockType = "toggle"
 	BlockTypeChildPage   BlockType = "child_page"
 	BlockTypeUnsupported BlockType = "unsupported"
+
+	BlockTypeImage    BlockType = "image"
+	BlockTypeVideo    BlockType = "video"
+	BlockTypeFile     BlockType = "file"
+	BlockTypePdf      BlockType = "pdf"
+	BlockTypeBookmark BlockType = "bookmark"
+	BlockTypeEmbed    BlockType = "embed"
+
+	BlockTypeCallout         BlockType = "callout"
+	BlockTypeQuote           BlockType = "quote"
+	BlockTypeCode            BlockType = "code"
+	BlockTypeEquation        BlockType = "equation"
+	BlockTypeDivider         BlockType = "divider"
+	BlockTypeTableOfContents BlockType = "table_of_contents"
+	BlockTypeBreadcrumb      BlockType = "breadcrumb"
+	BlockTypeLinkPreview     BlockType = "link_preview"
+	BlockTypeLinkToPage      BlockType = "link_to_page"
+	BlockTypeChildDatabase   BlockType = "child_database"
+)
+
+const (
+	FileTypeFile     FileType = "file"
+	FileTypeExternal FileType = "external"
+)
+
+const (
+	IconTypeEmoji    IconType = "emoji"
+	IconTypeFile     IconType = "file"
+	IconTypeExternal IconType = "external"
+)
+
+const (
+	ErrorCodeInvalidJSON         ErrorCode = "invalid_json"
+	ErrorCodeInvalidRequestURL   ErrorCode = "invalid_request_url"
+	ErrorCodeInvalidRequest      ErrorCode = "invalid_request"
+	ErrorCodeValidationError     ErrorCode = "validation_error"
+	ErrorCodeUnauthorized        ErrorCode = "unauthorized"
+	ErrorCodeRestrictedResource  ErrorCode = "restricted_resource"
+	ErrorCodeObjectNotFound      ErrorCode = "object_not_found"
+	ErrorCodeConflictError       ErrorCode = "conflict_error"
+	ErrorCodeRateLimited         ErrorCode = "rate_limited"
+	ErrorCodeInternalServerError ErrorCode = "internal_server_error"
+	ErrorCodeServiceUnavailable  ErrorCode = "service_unavailable"
 )
@@ -18,6 +18,10 @@ type DatabaseService interface {
 	Get(context.Context, DatabaseID) (*Database, error)
 	List(context.Context, *Pagination) (*DatabaseListResponse, error)
 	Query(context.Context, DatabaseID, *DatabaseQueryRequest) (*DatabaseQueryResponse, error)
+	Create(context.Context, *DatabaseCreateRequest) (*Database, error)
+	Update(context.Context, DatabaseID, *DatabaseUpdateRequest) (*Database, error)
+	ListAll(context.Context, *Pagination) *DatabaseIterator
+	QueryAll(context.Context, DatabaseID, *DatabaseQueryRequest) *PageIterator
 }
 
 type DatabaseClient struct {
@@ -26,59 +30,152 @@ type DatabaseClient struct {
 
 // Get https://developers.notion.com/reference/get-database
 func (dc *DatabaseClient) Get(ctx context.Context, id DatabaseID) (*Database, error) {
-	res, err := dc.apiClient.request(ctx, http.MethodGet, fmt.Sprintf("databases/%s", id.String()), nil, nil)
-	if err != nil {
-		return nil, err
+	call := &Call{
+		Operation: "databases.get",
+		ObjectID:  id.String(),
+		Method:    http.MethodGet,
+		Path:      fmt.Sprintf("databases/%s", id.String()),
+		decode:    decodeJSON(&Database{}),
 	}
-	var response Database
-
-	err = json.NewDecoder(res.Body).Decode(&response)
+	res, err := dc.apiClient.call(ctx, call)
 	if err != nil {
 		return nil, err
 	}
 
-	return &response, nil
+	response, ok := res.(*Database)
+	if !ok {
+		return nil, unexpectedResponse(call, res)
+	}
+	return response, nil
 }
 
 // List https://developers.notion.com/reference/get-databases
 func (dc *DatabaseClient) List(ctx context.Context, pagination *Pagination) (*DatabaseListResponse, error) {
-	res, err := dc.apiClient.request(ctx, http.MethodGet, "databases", pagination.ToQuery(), nil)
+	call := &Call{
+		Operation: "databases.list",
+		Method:    http.MethodGet,
+		Path:      "databases",
+		Query:     pagination.ToQuery(),
+		decode:    decodeJSON(&DatabaseListResponse{}),
+	}
+	res, err := dc.apiClient.call(ctx, call)
 	if err != nil {
 		return nil, err
 	}
 
-	var response DatabaseListResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
+	response, ok := res.(*DatabaseListResponse)
+	if !ok {
+		return nil, unexpectedResponse(call, res)
+	}
+	return response, nil
+}
+
+// Query https://developers.notion.com/reference/post-database-query
+func (dc *DatabaseClient) Query(ctx context.Context, id DatabaseID, requestBody *DatabaseQueryRequest) (*DatabaseQueryResponse, error) {
+	call := &Call{
+		Operation: "databases.query",
+		ObjectID:  id.String(),
+		Method:    http.MethodPost,
+		Path:      fmt.Sprintf("databases/%s/query", id.String()),
+		Body:      requestBody,
+		decode:    decodeJSON(&DatabaseQueryResponse{}),
+	}
+	res, err := dc.apiClient.call(ctx, call)
 	if err != nil {
 		return nil, err
 	}
 
-	return &response, nil
+	response, ok := res.(*DatabaseQueryResponse)
+	if !ok {
+		return nil, unexpectedResponse(call, res)
+	}
+	return response, nil
 }
 
-// Query https://developers.notion.com/reference/post-database-query
-func (dc *DatabaseClient) Query(ctx context.Context, id DatabaseID, requestBody *DatabaseQueryRequest) (*DatabaseQueryResponse, error) {
-	res, err := dc.apiClient.request(ctx, http.MethodPost, fmt.Sprintf("databases/%s/query", id.String()), nil, requestBody)
+// Create https://developers.notion.com/reference/create-a-database
+func (dc *DatabaseClient) Create(ctx context.Context, requestBody *DatabaseCreateRequest) (*Database, error) {
+	call := &Call{
+		Operation: "databases.create",
+		Method:    http.MethodPost,
+		Path:      "databases",
+		Body:      requestBody,
+		decode:    decodeJSON(&Database{}),
+	}
+	res, err := dc.apiClient.call(ctx, call)
 	if err != nil {
 		return nil, err
 	}
 
-	var response DatabaseQueryResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
+	response, ok := res.(*Database)
+	if !ok {
+		return nil, unexpectedResponse(call, res)
+	}
+	return response, nil
+}
+
+// Update https://developers.notion.com/reference/update-a-database
+func (dc *DatabaseClient) Update(ctx context.Context, id DatabaseID, requestBody *DatabaseUpdateRequest) (*Database, error) {
+	call := &Call{
+		Operation: "databases.update",
+		ObjectID:  id.String(),
+		Method:    http.MethodPatch,
+		Path:      fmt.Sprintf("databases/%s", id.String()),
+		Body:      requestBody,
+		decode:    decodeJSON(&Database{}),
+	}
+	res, err := dc.apiClient.call(ctx, call)
 	if err != nil {
 		return nil, err
 	}
 
-	return &response, nil
+	response, ok := res.(*Database)
+	if !ok {
+		return nil, unexpectedResponse(call, res)
+	}
+	return response, nil
+}
+
+// ListAll iterates over all databases, starting at pagination.StartCursor if set
+func (dc *DatabaseClient) ListAll(ctx context.Context, pagination *Pagination) *DatabaseIterator {
+	it := &DatabaseIterator{}
+	it.iterator = newIterator(ctx, startCursor(pagination), func(ctx context.Context, cursor Cursor) (int, Cursor, bool, error) {
+		res, err := dc.List(ctx, paginationFrom(pagination, cursor))
+		if err != nil {
+			return 0, "", false, err
+		}
+		it.items = res.Results
+		return len(res.Results), Cursor(res.NextCursor), res.HasMore, nil
+	})
+	return it
+}
+
+// QueryAll iterates over all pages matching the request, starting at
+// requestBody.StartCursor if set
+func (dc *DatabaseClient) QueryAll(ctx context.Context, id DatabaseID, requestBody *DatabaseQueryRequest) *PageIterator {
+	var request DatabaseQueryRequest
+	if requestBody != nil {
+		request = *requestBody
+	}
+	it := &PageIterator{}
+	it.iterator = newIterator(ctx, request.StartCursor, func(ctx context.Context, cursor Cursor) (int, Cursor, bool, error) {
+		request.StartCursor = cursor
+		res, err := dc.Query(ctx, id, &request)
+		if err != nil {
+			return 0, "", false, err
+		}
+		it.items = res.Results
+		return len(res.Results), res.NextCursor, res.HasMore, nil
+	})
+	return it
 }
 
 type Database struct {
-	Object         ObjectType `json:"object"`
-	ID             ObjectID   `json:"id"`
-	CreatedTime    time.Time  `json:"created_time"`
-	LastEditedTime time.Time  `json:"last_edited_time"`
-	Title          Paragraph  `json:"title"`
-	Properties     Properties `json:"properties"`
+	Object         ObjectType      `json:"object"`
+	ID             ObjectID        `json:"id"`
+	CreatedTime    time.Time       `json:"created_time"`
+	LastEditedTime time.Time       `json:"last_edited_time"`
+	Title          Paragraph       `json:"title"`
+	Properties     PropertyConfigs `json:"properties"`
 }
 
 func (db *Database) GetObject() ObjectType {
@@ -93,7 +190,12 @@ type DatabaseListResponse struct {
 }
 
 type DatabaseQueryRequest struct {
+	// Filter limits the results, it takes precedence over PropertyFilter
+	// and CompoundFilter
+	Filter Filter
+	// Deprecated: use Filter
 	PropertyFilter *PropertyFilter
+	// Deprecated: use Filter
 	CompoundFilter *CompoundFilter
 	Sorts          []SortObject `json:"sorts,omitempty"`
 	StartCursor    Cursor       `json:"start_cursor,omitempty"`
@@ -102,9 +204,11 @@ type DatabaseQueryRequest struct {
 
 func (qr *DatabaseQueryRequest) MarshalJSON() ([]byte, error) {
 	var filter interface{}
-	if qr.PropertyFilter != nil {
+	if qr.Filter != nil {
+		filter = qr.Filter
+	} else if qr.PropertyFilter != nil {
 		filter = qr.PropertyFilter
-	} else if qr.CompoundFilter != nil{
+	} else if qr.CompoundFilter != nil {
 		filter = qr.CompoundFilter
 	}
 	return json.Marshal(struct {
@@ -120,6 +224,21 @@ func (qr *DatabaseQueryRequest) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// DatabaseCreateRequest creates a database as a child of a page. Properties is
+// the schema of the database and must contain exactly one title property.
+type DatabaseCreateRequest struct {
+	Parent     Parent          `json:"parent"`
+	Title      Paragraph       `json:"title"`
+	Properties PropertyConfigs `json:"properties"`
+}
+
+// DatabaseUpdateRequest renames a database and changes its schema. Properties
+// are keyed by their current name or ID.
+type DatabaseUpdateRequest struct {
+	Title      Paragraph                  `json:"title,omitempty"`
+	Properties map[string]*PropertyUpdate `json:"properties,omitempty"`
+}
+
 type DatabaseQueryResponse struct {
 	Object     ObjectType `json:"object"`
 	Results    []Page     `json:"results"`
@@ -3,6 +3,7 @@ package notionapi_test
 import (
 	"context"
 	"github.com/jomei/notionapi"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"testing"
@@ -38,7 +39,7 @@ func TestDatabaseClient(t *testing.T) {
 					Title: []notionapi.RichText{
 						{
 							Type:        notionapi.ObjectTypeText,
-							Text:        notionapi.Text{Content: "Test Database", Link: ""},
+							Text:        notionapi.Text{Content: "Test Database"},
 							Annotations: &notionapi.Annotations{Color: "default"},
 							PlainText:   "Test Database",
 							Href:        "",
@@ -212,6 +213,150 @@ func TestDatabaseClient(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("Create", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			filePath   string
+			statusCode int
+			request    *notionapi.DatabaseCreateRequest
+			wantBody   string
+			want       notionapi.PropertyConfigs
+			wantErr    bool
+			err        error
+		}{
+			{
+				name:       "returns a new database",
+				filePath:   "testdata/database_create.json",
+				statusCode: http.StatusOK,
+				request: &notionapi.DatabaseCreateRequest{
+					Parent: notionapi.Parent{
+						Type:   notionapi.ParentTypePageID,
+						PageID: "some_page_id",
+					},
+					Title: notionapi.Paragraph{
+						{Text: notionapi.Text{Content: "Test Database"}},
+					},
+					Properties: notionapi.PropertyConfigs{
+						"Name": notionapi.TitlePropertyConfig{Type: notionapi.PropertyTypeTitle},
+						"Price": notionapi.NumberPropertyConfig{
+							Type:   notionapi.PropertyTypeNumber,
+							Number: notionapi.NumberFormat{Format: notionapi.FormatDollar},
+						},
+					},
+				},
+				wantBody: `{"parent":{"type":"page_id","page_id":"some_page_id"},"title":[{"text":{"content":"Test Database"}}],"properties":{"Name":{"type":"title","title":{}},"Price":{"type":"number","number":{"format":"dollar"}}}}`,
+				want: notionapi.PropertyConfigs{
+					"Name": &notionapi.TitlePropertyConfig{ID: "title", Type: notionapi.PropertyTypeTitle},
+					"Price": &notionapi.NumberPropertyConfig{
+						ID:     "evWq",
+						Type:   notionapi.PropertyTypeNumber,
+						Number: notionapi.NumberFormat{Format: notionapi.FormatDollar},
+					},
+					"Status": &notionapi.SelectPropertyConfig{
+						ID:     "Ikk%7C",
+						Type:   notionapi.PropertyTypeSelect,
+						Select: notionapi.Select{Options: []notionapi.Option{{ID: "some_id", Name: "Done", Color: notionapi.ColorGreen}}},
+					},
+					"Total": &notionapi.FormulaPropertyConfig{
+						ID:      "%3Ah%5B",
+						Type:    notionapi.PropertyTypeFormula,
+						Formula: notionapi.FormulaConfig{Expression: `prop("Price") * 2`},
+					},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var body []byte
+				c := newTestClient(func(req *http.Request) *http.Response {
+					var err error
+					body, err = ioutil.ReadAll(req.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return newMockedClientResponse(t, tt.filePath, tt.statusCode)
+				})
+				client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+				got, err := client.Database.Create(context.Background(), tt.request)
+
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+				if string(body) != tt.wantBody {
+					t.Errorf("Create() request body got = %s, want %s", body, tt.wantBody)
+				}
+				if !reflect.DeepEqual(got.Properties, tt.want) {
+					t.Errorf("Create() got = %v, want %v", got.Properties, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			filePath   string
+			statusCode int
+			id         notionapi.DatabaseID
+			request    *notionapi.DatabaseUpdateRequest
+			wantBody   string
+			wantErr    bool
+			err        error
+		}{
+			{
+				name:       "renames, retypes and removes properties",
+				id:         "some_id",
+				filePath:   "testdata/database_update.json",
+				statusCode: http.StatusOK,
+				request: &notionapi.DatabaseUpdateRequest{
+					Title: notionapi.Paragraph{
+						{Text: notionapi.Text{Content: "Renamed"}},
+					},
+					Properties: map[string]*notionapi.PropertyUpdate{
+						"Price": {Name: "Cost"},
+						"Status": {
+							Property: notionapi.SelectPropertyConfig{
+								Type:   notionapi.PropertyTypeSelect,
+								Select: notionapi.Select{Options: []notionapi.Option{{Name: "Done", Color: notionapi.ColorGreen}}},
+							},
+						},
+						"Total": nil,
+					},
+				},
+				wantBody: `{"title":[{"text":{"content":"Renamed"}}],"properties":{"Price":{"name":"Cost"},"Status":{"select":{"options":[{"name":"Done","color":"green"}]},"type":"select"},"Total":null}}`,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var body []byte
+				c := newTestClient(func(req *http.Request) *http.Response {
+					var err error
+					body, err = ioutil.ReadAll(req.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return newMockedClientResponse(t, tt.filePath, tt.statusCode)
+				})
+				client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+				got, err := client.Database.Update(context.Background(), tt.id, tt.request)
+
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+				if string(body) != tt.wantBody {
+					t.Errorf("Update() request body got = %s, want %s", body, tt.wantBody)
+				}
+				if _, ok := got.Properties["Cost"]; !ok {
+					t.Errorf("Update() got = %v, want renamed property", got.Properties)
+				}
+			})
+		}
+	})
 }
 
 func TestDatabaseQueryRequest_MarshalJSON(t *testing.T) {
@@ -220,7 +365,7 @@ func TestDatabaseQueryRequest_MarshalJSON(t *testing.T) {
 		t.Error(err)
 		return
 	}
-	dateObj := notionapi.Date(timeObj)
+	dateObj := notionapi.NewDateTime(timeObj)
 	tests := []struct {
 		name    string
 		req     *notionapi.DatabaseQueryRequest
@@ -279,13 +424,41 @@ func TestDatabaseQueryRequest_MarshalJSON(t *testing.T) {
 			},
 			want: []byte(`{"filter":{"or":[{"property":"Status","select":{"equals":"Reading"}},{"property":"Publisher","select":{"equals":"NYT"}}]}}`),
 		},
+		{
+			name: "nested compound filter",
+			req: &notionapi.DatabaseQueryRequest{
+				Filter: notionapi.AndCompoundFilter{
+					&notionapi.PropertyFilter{
+						Property: "Status",
+						Select: &notionapi.SelectFilterCondition{
+							Equals: "Reading",
+						},
+					},
+					notionapi.OrCompoundFilter{
+						&notionapi.PropertyFilter{
+							Property: "Publisher",
+							Select: &notionapi.SelectFilterCondition{
+								Equals: "NYT",
+							},
+						},
+						&notionapi.PropertyFilter{
+							Property: "Publisher",
+							Select: &notionapi.SelectFilterCondition{
+								IsEmpty: true,
+							},
+						},
+					},
+				},
+			},
+			want: []byte(`{"filter":{"and":[{"property":"Status","select":{"equals":"Reading"}},{"or":[{"property":"Publisher","select":{"equals":"NYT"}},{"property":"Publisher","select":{"is_empty":true}}]}]}}`),
+		},
 		{
 			name: "date filter",
 			req: &notionapi.DatabaseQueryRequest{
 				PropertyFilter: &notionapi.PropertyFilter{
 					Property: "created_at",
 					Date: &notionapi.DateFilterCondition{
-						Equals:   &dateObj,
+						Equals:   dateObj,
 						PastWeek: &struct{}{},
 					},
 				},
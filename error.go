@@ -1,5 +1,7 @@
 package notionapi
 
+import "time"
+
 type ErrorCode string
 
 type Error struct {
@@ -7,6 +9,9 @@ type Error struct {
 	Status  int        `json:"status"`
 	Code    ErrorCode  `json:"code"`
 	Message string     `json:"message"`
+
+	// retryAfter is the delay requested by the Retry-After header
+	retryAfter time.Duration
 }
 
 func (e *Error) Error() string {
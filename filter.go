@@ -1,9 +1,100 @@
 package notionapi
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Filter limits the pages returned by a database query. It is either a
+// *PropertyFilter or a compound filter combining other filters, see the
+// filter package for a builder.
+type Filter interface {
+	// Validate checks that every property filter has exactly one condition
+	// and that compound filters are not empty.
+	Validate() error
+	filter()
+}
+
 type FilterOperator string
 
+// CompoundFilter combines property filters with an operator.
+//
+// Deprecated: CompoundFilter can not be nested, use AndCompoundFilter and
+// OrCompoundFilter instead.
 type CompoundFilter map[FilterOperator][]PropertyFilter
 
+func (f CompoundFilter) filter() {}
+
+func (f CompoundFilter) Validate() error {
+	if len(f) != 1 {
+		return fmt.Errorf("compound filter needs exactly one operator, got %d", len(f))
+	}
+	for operator, filters := range f {
+		if operator != FilterOperatorAND && operator != FilterOperatorOR {
+			return fmt.Errorf("unknown filter operator %q", operator)
+		}
+		if len(filters) == 0 {
+			return fmt.Errorf("empty %s filter", operator)
+		}
+		for i := range filters {
+			if err := filters[i].Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AndCompoundFilter matches pages matching all of its filters
+type AndCompoundFilter []Filter
+
+func (f AndCompoundFilter) filter() {}
+
+func (f AndCompoundFilter) Validate() error {
+	return validateCompound(FilterOperatorAND, f)
+}
+
+func (f AndCompoundFilter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[FilterOperator][]Filter{FilterOperatorAND: nonNilFilters(f)})
+}
+
+// OrCompoundFilter matches pages matching any of its filters
+type OrCompoundFilter []Filter
+
+func (f OrCompoundFilter) filter() {}
+
+func (f OrCompoundFilter) Validate() error {
+	return validateCompound(FilterOperatorOR, f)
+}
+
+func (f OrCompoundFilter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[FilterOperator][]Filter{FilterOperatorOR: nonNilFilters(f)})
+}
+
+func validateCompound(operator FilterOperator, filters []Filter) error {
+	if len(filters) == 0 {
+		return fmt.Errorf("empty %s filter", operator)
+	}
+	for _, f := range filters {
+		if f == nil {
+			return fmt.Errorf("nil filter in %s filter", operator)
+		}
+		if err := f.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nonNilFilters makes sure compound filters marshal to a list
+func nonNilFilters(filters []Filter) []Filter {
+	if filters == nil {
+		return []Filter{}
+	}
+	return filters
+}
+
 type Condition string
 
 type PropertyFilter struct {
@@ -20,6 +111,143 @@ type PropertyFilter struct {
 	Formula     *FormulaFilterCondition     `json:"formula,omitempty"`
 }
 
+func (f *PropertyFilter) filter() {}
+
+func (f *PropertyFilter) Validate() error {
+	if f.Property == "" {
+		return fmt.Errorf("property filter without property")
+	}
+	var conditions []condition
+	if f.Text != nil {
+		conditions = append(conditions, f.Text)
+	}
+	if f.Number != nil {
+		conditions = append(conditions, f.Number)
+	}
+	if f.Checkbox != nil {
+		conditions = append(conditions, f.Checkbox)
+	}
+	if f.Select != nil {
+		conditions = append(conditions, f.Select)
+	}
+	if f.MultiSelect != nil {
+		conditions = append(conditions, f.MultiSelect)
+	}
+	if f.Date != nil {
+		conditions = append(conditions, f.Date)
+	}
+	if f.People != nil {
+		conditions = append(conditions, f.People)
+	}
+	if f.Files != nil {
+		conditions = append(conditions, f.Files)
+	}
+	if f.Relation != nil {
+		conditions = append(conditions, f.Relation)
+	}
+	if f.Formula != nil {
+		conditions = append(conditions, f.Formula)
+	}
+	if len(conditions) != 1 {
+		return fmt.Errorf("filter on %q needs exactly one condition, got %d", f.Property, len(conditions))
+	}
+	return conditions[0].validate(f.Property)
+}
+
+// condition is a filter condition of a single property type
+type condition interface {
+	// validate checks that exactly one comparison is set
+	validate(property string) error
+}
+
+// explicit records the comparisons of a condition that were set with a
+// setter. They are sent even if they hold the zero value.
+type explicit uint16
+
+var explicitBits = map[Condition]explicit{
+	ConditionEquals:               1 << 0,
+	ConditionDoesNotEqual:         1 << 1,
+	ConditionContains:             1 << 2,
+	ConditionDoesNotContain:       1 << 3,
+	ConditionDoesStartsWith:       1 << 4,
+	ConditionDoesEndsWith:         1 << 5,
+	ConditionGreaterThan:          1 << 6,
+	ConditionLessThan:             1 << 7,
+	ConditionGreaterThanOrEqualTo: 1 << 8,
+	ConditionLessThanOrEqualTo:    1 << 9,
+}
+
+func (e *explicit) mark(c Condition) {
+	*e |= explicitBits[c]
+}
+
+func (e explicit) has(c Condition) bool {
+	return e&explicitBits[c] != 0
+}
+
+// comparison is a comparison of a condition and whether it is sent
+type comparison struct {
+	condition Condition
+	value     interface{}
+	set       bool
+}
+
+func containsComparisons(contains, doesNotContain string, isEmpty, isNotEmpty bool, set explicit) []comparison {
+	return []comparison{
+		{ConditionContains, contains, contains != "" || set.has(ConditionContains)},
+		{ConditionDoesNotContain, doesNotContain, doesNotContain != "" || set.has(ConditionDoesNotContain)},
+		{ConditionDoesIsEmpty, true, isEmpty},
+		{ConditionIsNotEmpty, true, isNotEmpty},
+	}
+}
+
+func has(comparisons []comparison, condition Condition) bool {
+	for _, c := range comparisons {
+		if c.condition == condition {
+			return c.set
+		}
+	}
+	return false
+}
+
+// marshalComparisons marshals the comparisons which are set to an object in
+// their order
+func marshalComparisons(comparisons []comparison) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{")
+	for _, c := range comparisons {
+		if !c.set {
+			continue
+		}
+		value, err := json.Marshal(c.value)
+		if err != nil {
+			return nil, err
+		}
+		if b.Len() > 1 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, "%q:%s", c.condition, value)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+func exactlyOne(property, kind string, comparisons []comparison) error {
+	n := 0
+	for _, c := range comparisons {
+		if c.set {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("%s filter on %q needs exactly one comparison, got %d", kind, property, n)
+	}
+	return nil
+}
+
+// TextFilterCondition compares text values. Comparisons with a non-empty
+// value are sent, the setters send them even if they are empty. Use IsEmpty
+// to match empty values.
 type TextFilterCondition struct {
 	Equals         string `json:"equals,omitempty"`
 	DoesNotEqual   string `json:"does_not_equal,omitempty"`
@@ -29,59 +257,381 @@ type TextFilterCondition struct {
 	EndsWith       string `json:"ends_with,omitempty"`
 	IsEmpty        bool   `json:"is_empty,omitempty"`
 	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
+
+	set explicit
+}
+
+func (c *TextFilterCondition) SetEquals(s string) *TextFilterCondition {
+	c.Equals = s
+	c.set.mark(ConditionEquals)
+	return c
+}
+
+func (c *TextFilterCondition) SetDoesNotEqual(s string) *TextFilterCondition {
+	c.DoesNotEqual = s
+	c.set.mark(ConditionDoesNotEqual)
+	return c
+}
+
+func (c *TextFilterCondition) SetContains(s string) *TextFilterCondition {
+	c.Contains = s
+	c.set.mark(ConditionContains)
+	return c
+}
+
+func (c *TextFilterCondition) SetDoesNotContain(s string) *TextFilterCondition {
+	c.DoesNotContain = s
+	c.set.mark(ConditionDoesNotContain)
+	return c
+}
+
+func (c *TextFilterCondition) SetStartsWith(s string) *TextFilterCondition {
+	c.StartsWith = s
+	c.set.mark(ConditionDoesStartsWith)
+	return c
+}
+
+func (c *TextFilterCondition) SetEndsWith(s string) *TextFilterCondition {
+	c.EndsWith = s
+	c.set.mark(ConditionDoesEndsWith)
+	return c
+}
+
+func (c TextFilterCondition) comparisons() []comparison {
+	return []comparison{
+		{ConditionEquals, c.Equals, c.Equals != "" || c.set.has(ConditionEquals)},
+		{ConditionDoesNotEqual, c.DoesNotEqual, c.DoesNotEqual != "" || c.set.has(ConditionDoesNotEqual)},
+		{ConditionContains, c.Contains, c.Contains != "" || c.set.has(ConditionContains)},
+		{ConditionDoesNotContain, c.DoesNotContain, c.DoesNotContain != "" || c.set.has(ConditionDoesNotContain)},
+		{ConditionDoesStartsWith, c.StartsWith, c.StartsWith != "" || c.set.has(ConditionDoesStartsWith)},
+		{ConditionDoesEndsWith, c.EndsWith, c.EndsWith != "" || c.set.has(ConditionDoesEndsWith)},
+		{ConditionDoesIsEmpty, true, c.IsEmpty},
+		{ConditionIsNotEmpty, true, c.IsNotEmpty},
+	}
+}
+
+// Has reports whether the comparison is sent
+func (c TextFilterCondition) Has(condition Condition) bool {
+	return has(c.comparisons(), condition)
+}
+
+func (c TextFilterCondition) MarshalJSON() ([]byte, error) {
+	return marshalComparisons(c.comparisons())
 }
 
+func (c *TextFilterCondition) validate(property string) error {
+	return exactlyOne(property, "text", c.comparisons())
+}
+
+// NumberFilterCondition compares number values. Comparisons with a non-zero
+// value are sent, the setters send them even if they are zero:
+//
+//	new(notionapi.NumberFilterCondition).SetGreaterThan(0)
 type NumberFilterCondition struct {
 	Equals               float64 `json:"equals,omitempty"`
 	DoesNotEqual         float64 `json:"does_not_equal,omitempty"`
 	GreaterThan          float64 `json:"greater_than,omitempty"`
 	LessThan             float64 `json:"less_than,omitempty"`
-	GreaterThanOrEqualTo float64 `json:"greater_than_or_equal_to"`
-	LessThanOrEqualTo    float64 `json:"less_than_or_equal_to"`
+	GreaterThanOrEqualTo float64 `json:"greater_than_or_equal_to,omitempty"`
+	LessThanOrEqualTo    float64 `json:"less_than_or_equal_to,omitempty"`
 	IsEmpty              bool    `json:"is_empty,omitempty"`
 	IsNotEmpty           bool    `json:"is_not_empty,omitempty"`
+
+	set explicit
+}
+
+func (c *NumberFilterCondition) SetEquals(n float64) *NumberFilterCondition {
+	c.Equals = n
+	c.set.mark(ConditionEquals)
+	return c
+}
+
+func (c *NumberFilterCondition) SetDoesNotEqual(n float64) *NumberFilterCondition {
+	c.DoesNotEqual = n
+	c.set.mark(ConditionDoesNotEqual)
+	return c
+}
+
+func (c *NumberFilterCondition) SetGreaterThan(n float64) *NumberFilterCondition {
+	c.GreaterThan = n
+	c.set.mark(ConditionGreaterThan)
+	return c
+}
+
+func (c *NumberFilterCondition) SetLessThan(n float64) *NumberFilterCondition {
+	c.LessThan = n
+	c.set.mark(ConditionLessThan)
+	return c
+}
+
+func (c *NumberFilterCondition) SetGreaterThanOrEqualTo(n float64) *NumberFilterCondition {
+	c.GreaterThanOrEqualTo = n
+	c.set.mark(ConditionGreaterThanOrEqualTo)
+	return c
+}
+
+func (c *NumberFilterCondition) SetLessThanOrEqualTo(n float64) *NumberFilterCondition {
+	c.LessThanOrEqualTo = n
+	c.set.mark(ConditionLessThanOrEqualTo)
+	return c
+}
+
+func (c NumberFilterCondition) comparisons() []comparison {
+	return []comparison{
+		{ConditionEquals, c.Equals, c.Equals != 0 || c.set.has(ConditionEquals)},
+		{ConditionDoesNotEqual, c.DoesNotEqual, c.DoesNotEqual != 0 || c.set.has(ConditionDoesNotEqual)},
+		{ConditionGreaterThan, c.GreaterThan, c.GreaterThan != 0 || c.set.has(ConditionGreaterThan)},
+		{ConditionLessThan, c.LessThan, c.LessThan != 0 || c.set.has(ConditionLessThan)},
+		{ConditionGreaterThanOrEqualTo, c.GreaterThanOrEqualTo, c.GreaterThanOrEqualTo != 0 || c.set.has(ConditionGreaterThanOrEqualTo)},
+		{ConditionLessThanOrEqualTo, c.LessThanOrEqualTo, c.LessThanOrEqualTo != 0 || c.set.has(ConditionLessThanOrEqualTo)},
+		{ConditionDoesIsEmpty, true, c.IsEmpty},
+		{ConditionIsNotEmpty, true, c.IsNotEmpty},
+	}
+}
+
+// Has reports whether the comparison is sent
+func (c NumberFilterCondition) Has(condition Condition) bool {
+	return has(c.comparisons(), condition)
+}
+
+func (c NumberFilterCondition) MarshalJSON() ([]byte, error) {
+	return marshalComparisons(c.comparisons())
+}
+
+func (c *NumberFilterCondition) validate(property string) error {
+	return exactlyOne(property, "number", c.comparisons())
 }
 
+// CheckboxFilterCondition compares checkbox values. Comparisons with true are
+// sent, the setters send them with false as well:
+//
+//	new(notionapi.CheckboxFilterCondition).SetEquals(false)
 type CheckboxFilterCondition struct {
 	Equals       bool `json:"equals,omitempty"`
 	DoesNotEqual bool `json:"does_not_equal,omitempty"`
+
+	set explicit
+}
+
+func (c *CheckboxFilterCondition) SetEquals(v bool) *CheckboxFilterCondition {
+	c.Equals = v
+	c.set.mark(ConditionEquals)
+	return c
 }
 
+func (c *CheckboxFilterCondition) SetDoesNotEqual(v bool) *CheckboxFilterCondition {
+	c.DoesNotEqual = v
+	c.set.mark(ConditionDoesNotEqual)
+	return c
+}
+
+func (c CheckboxFilterCondition) comparisons() []comparison {
+	return []comparison{
+		{ConditionEquals, c.Equals, c.Equals || c.set.has(ConditionEquals)},
+		{ConditionDoesNotEqual, c.DoesNotEqual, c.DoesNotEqual || c.set.has(ConditionDoesNotEqual)},
+	}
+}
+
+// Has reports whether the comparison is sent
+func (c CheckboxFilterCondition) Has(condition Condition) bool {
+	return has(c.comparisons(), condition)
+}
+
+func (c CheckboxFilterCondition) MarshalJSON() ([]byte, error) {
+	return marshalComparisons(c.comparisons())
+}
+
+func (c *CheckboxFilterCondition) validate(property string) error {
+	return exactlyOne(property, "checkbox", c.comparisons())
+}
+
+// SelectFilterCondition compares the option of select values by name
 type SelectFilterCondition struct {
 	Equals       string `json:"equals,omitempty"`
 	DoesNotEqual string `json:"does_not_equal,omitempty"`
 	IsEmpty      bool   `json:"is_empty,omitempty"`
 	IsNotEmpty   bool   `json:"is_not_empty,omitempty"`
+
+	set explicit
+}
+
+func (c *SelectFilterCondition) SetEquals(option string) *SelectFilterCondition {
+	c.Equals = option
+	c.set.mark(ConditionEquals)
+	return c
+}
+
+func (c *SelectFilterCondition) SetDoesNotEqual(option string) *SelectFilterCondition {
+	c.DoesNotEqual = option
+	c.set.mark(ConditionDoesNotEqual)
+	return c
 }
 
+func (c SelectFilterCondition) comparisons() []comparison {
+	return []comparison{
+		{ConditionEquals, c.Equals, c.Equals != "" || c.set.has(ConditionEquals)},
+		{ConditionDoesNotEqual, c.DoesNotEqual, c.DoesNotEqual != "" || c.set.has(ConditionDoesNotEqual)},
+		{ConditionDoesIsEmpty, true, c.IsEmpty},
+		{ConditionIsNotEmpty, true, c.IsNotEmpty},
+	}
+}
+
+// Has reports whether the comparison is sent
+func (c SelectFilterCondition) Has(condition Condition) bool {
+	return has(c.comparisons(), condition)
+}
+
+func (c SelectFilterCondition) MarshalJSON() ([]byte, error) {
+	return marshalComparisons(c.comparisons())
+}
+
+func (c *SelectFilterCondition) validate(property string) error {
+	return exactlyOne(property, "select", c.comparisons())
+}
+
+// MultiSelectFilterCondition checks whether multi_select values contain an
+// option by name
 type MultiSelectFilterCondition struct {
 	Contains       string `json:"contains,omitempty"`
 	DoesNotContain string `json:"does_not_contain,omitempty"`
 	IsEmpty        bool   `json:"is_empty,omitempty"`
 	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
+
+	set explicit
+}
+
+func (c *MultiSelectFilterCondition) SetContains(option string) *MultiSelectFilterCondition {
+	c.Contains = option
+	c.set.mark(ConditionContains)
+	return c
+}
+
+func (c *MultiSelectFilterCondition) SetDoesNotContain(option string) *MultiSelectFilterCondition {
+	c.DoesNotContain = option
+	c.set.mark(ConditionDoesNotContain)
+	return c
+}
+
+func (c MultiSelectFilterCondition) comparisons() []comparison {
+	return containsComparisons(c.Contains, c.DoesNotContain, c.IsEmpty, c.IsNotEmpty, c.set)
+}
+
+// Has reports whether the comparison is sent
+func (c MultiSelectFilterCondition) Has(condition Condition) bool {
+	return has(c.comparisons(), condition)
+}
+
+func (c MultiSelectFilterCondition) MarshalJSON() ([]byte, error) {
+	return marshalComparisons(c.comparisons())
+}
+
+func (c *MultiSelectFilterCondition) validate(property string) error {
+	return exactlyOne(property, "multi_select", c.comparisons())
 }
 
+// DateFilterCondition compares dates. Only the Start of a DateObject is
+// used, date-only dates compare whole days. See NewDate and NewDateTime.
 type DateFilterCondition struct {
-	Equals     *Date     `json:"equals,omitempty"`
-	Before     *Date     `json:"before,omitempty"`
-	After      *Date     `json:"after,omitempty"`
-	OnOrBefore *Date     `json:"on_or_before,omitempty"`
-	OnOrAfter  *Date     `json:"on_or_after,omitempty"`
-	PastWeek   *struct{} `json:"past_week,omitempty"`
-	PastMonth  *struct{} `json:"past_month,omitempty"`
-	PastYear   *struct{} `json:"past_year,omitempty"`
-	NextWeek   *struct{} `json:"next_week,omitempty"`
-	NextMonth  *struct{} `json:"next_month,omitempty"`
-	NextYear   *struct{} `json:"next_year,omitempty"`
-	IsEmpty    bool      `json:"is_empty,omitempty"`
-	IsNotEmpty bool      `json:"is_not_empty,omitempty"`
+	Equals     *DateObject `json:"equals,omitempty"`
+	Before     *DateObject `json:"before,omitempty"`
+	After      *DateObject `json:"after,omitempty"`
+	OnOrBefore *DateObject `json:"on_or_before,omitempty"`
+	OnOrAfter  *DateObject `json:"on_or_after,omitempty"`
+	PastWeek   *struct{}   `json:"past_week,omitempty"`
+	PastMonth  *struct{}   `json:"past_month,omitempty"`
+	PastYear   *struct{}   `json:"past_year,omitempty"`
+	NextWeek   *struct{}   `json:"next_week,omitempty"`
+	NextMonth  *struct{}   `json:"next_month,omitempty"`
+	NextYear   *struct{}   `json:"next_year,omitempty"`
+	IsEmpty    bool        `json:"is_empty,omitempty"`
+	IsNotEmpty bool        `json:"is_not_empty,omitempty"`
 }
 
+func (c *DateFilterCondition) validate(property string) error {
+	for _, d := range []*DateObject{c.Equals, c.Before, c.After, c.OnOrBefore, c.OnOrAfter} {
+		if d != nil && d.Start == nil {
+			return fmt.Errorf("date filter on %q compares with a date without start", property)
+		}
+	}
+	return exactlyOne(property, "date", c.comparisons())
+}
+
+func (c DateFilterCondition) comparisons() []comparison {
+	return []comparison{
+		{ConditionEquals, filterDate{c.Equals}, c.Equals != nil},
+		{ConditionBefore, filterDate{c.Before}, c.Before != nil},
+		{ConditionAfter, filterDate{c.After}, c.After != nil},
+		{ConditionOnOrBefore, filterDate{c.OnOrBefore}, c.OnOrBefore != nil},
+		{ConditionOnOrAfter, filterDate{c.OnOrAfter}, c.OnOrAfter != nil},
+		{ConditionPastWeek, struct{}{}, c.PastWeek != nil},
+		{ConditionPastMonth, struct{}{}, c.PastMonth != nil},
+		{ConditionPastYear, struct{}{}, c.PastYear != nil},
+		{ConditionNextWeek, struct{}{}, c.NextWeek != nil},
+		{ConditionNextMonth, struct{}{}, c.NextMonth != nil},
+		{ConditionNextYear, struct{}{}, c.NextYear != nil},
+		{ConditionDoesIsEmpty, true, c.IsEmpty},
+		{ConditionIsNotEmpty, true, c.IsNotEmpty},
+	}
+}
+
+// Has reports whether the comparison is sent
+func (c DateFilterCondition) Has(condition Condition) bool {
+	return has(c.comparisons(), condition)
+}
+
+// MarshalJSON sends the start of the compared dates as plain strings
+func (c DateFilterCondition) MarshalJSON() ([]byte, error) {
+	return marshalComparisons(c.comparisons())
+}
+
+// filterDate sends the start of a date as a plain string
+type filterDate struct{ date *DateObject }
+
+func (f filterDate) MarshalJSON() ([]byte, error) {
+	start, err := f.date.format(f.date.Start)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(start)
+}
+
+// PeopleFilterCondition checks whether people values contain a user by ID
 type PeopleFilterCondition struct {
 	Contains       string `json:"contains,omitempty"`
 	DoesNotContain string `json:"does_not_contain,omitempty"`
 	IsEmpty        bool   `json:"is_empty,omitempty"`
 	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
+
+	set explicit
+}
+
+func (c *PeopleFilterCondition) SetContains(id string) *PeopleFilterCondition {
+	c.Contains = id
+	c.set.mark(ConditionContains)
+	return c
+}
+
+func (c *PeopleFilterCondition) SetDoesNotContain(id string) *PeopleFilterCondition {
+	c.DoesNotContain = id
+	c.set.mark(ConditionDoesNotContain)
+	return c
+}
+
+func (c PeopleFilterCondition) comparisons() []comparison {
+	return containsComparisons(c.Contains, c.DoesNotContain, c.IsEmpty, c.IsNotEmpty, c.set)
+}
+
+// Has reports whether the comparison is sent
+func (c PeopleFilterCondition) Has(condition Condition) bool {
+	return has(c.comparisons(), condition)
+}
+
+func (c PeopleFilterCondition) MarshalJSON() ([]byte, error) {
+	return marshalComparisons(c.comparisons())
+}
+
+func (c *PeopleFilterCondition) validate(property string) error {
+	return exactlyOne(property, "people", c.comparisons())
 }
 
 type FilesFilterCondition struct {
@@ -89,11 +639,50 @@ type FilesFilterCondition struct {
 	IsNotEmpty bool `json:"is_not_empty,omitempty"`
 }
 
+func (c *FilesFilterCondition) validate(property string) error {
+	return exactlyOne(property, "files", []comparison{
+		{ConditionDoesIsEmpty, true, c.IsEmpty},
+		{ConditionIsNotEmpty, true, c.IsNotEmpty},
+	})
+}
+
+// RelationFilterCondition checks whether relation values contain a page by ID
 type RelationFilterCondition struct {
 	Contains       string `json:"contains,omitempty"`
 	DoesNotContain string `json:"does_not_contain,omitempty"`
 	IsEmpty        bool   `json:"is_empty,omitempty"`
 	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
+
+	set explicit
+}
+
+func (c *RelationFilterCondition) SetContains(id string) *RelationFilterCondition {
+	c.Contains = id
+	c.set.mark(ConditionContains)
+	return c
+}
+
+func (c *RelationFilterCondition) SetDoesNotContain(id string) *RelationFilterCondition {
+	c.DoesNotContain = id
+	c.set.mark(ConditionDoesNotContain)
+	return c
+}
+
+func (c RelationFilterCondition) comparisons() []comparison {
+	return containsComparisons(c.Contains, c.DoesNotContain, c.IsEmpty, c.IsNotEmpty, c.set)
+}
+
+// Has reports whether the comparison is sent
+func (c RelationFilterCondition) Has(condition Condition) bool {
+	return has(c.comparisons(), condition)
+}
+
+func (c RelationFilterCondition) MarshalJSON() ([]byte, error) {
+	return marshalComparisons(c.comparisons())
+}
+
+func (c *RelationFilterCondition) validate(property string) error {
+	return exactlyOne(property, "relation", c.comparisons())
 }
 
 type FormulaFilterCondition struct {
@@ -102,3 +691,23 @@ type FormulaFilterCondition struct {
 	Number   *NumberFilterCondition   `json:"number,omitempty"`
 	Date     *DateFilterCondition     `json:"date,omitempty"`
 }
+
+func (c *FormulaFilterCondition) validate(property string) error {
+	var conditions []condition
+	if c.Text != nil {
+		conditions = append(conditions, c.Text)
+	}
+	if c.Checkbox != nil {
+		conditions = append(conditions, c.Checkbox)
+	}
+	if c.Number != nil {
+		conditions = append(conditions, c.Number)
+	}
+	if c.Date != nil {
+		conditions = append(conditions, c.Date)
+	}
+	if len(conditions) != 1 {
+		return fmt.Errorf("formula filter on %q needs exactly one condition, got %d", property, len(conditions))
+	}
+	return conditions[0].validate(property)
+}
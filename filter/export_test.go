@@ -0,0 +1,10 @@
+package filter
+
+import "time"
+
+// SetNow fixes the time relative date conditions are evaluated against and
+// returns a function restoring it
+func SetNow(t time.Time) func() {
+	now = func() time.Time { return t }
+	return func() { now = time.Now }
+}
@@ -0,0 +1,452 @@
+// Package filter builds nested filters for database queries:
+//
+//	f := filter.And(
+//		filter.Text("Name").Contains("report"),
+//		filter.Or(
+//			filter.Select("Status").Equals("Done"),
+//			filter.Number("Priority").GreaterThan(2),
+//		),
+//	)
+//	res, err := client.Database.Query(ctx, id, &notionapi.DatabaseQueryRequest{Filter: f})
+//
+// Each property type has its own builder offering only the conditions Notion
+// accepts for it, and every built property filter has exactly one condition.
+package filter
+
+import (
+	"github.com/jomei/notionapi"
+)
+
+// And matches pages matching all filters
+func And(filters ...notionapi.Filter) notionapi.AndCompoundFilter {
+	return notionapi.AndCompoundFilter(filters)
+}
+
+// Or matches pages matching any of the filters
+func Or(filters ...notionapi.Filter) notionapi.OrCompoundFilter {
+	return notionapi.OrCompoundFilter(filters)
+}
+
+// Validate checks f, see notionapi.Filter
+func Validate(f notionapi.Filter) error {
+	if f == nil {
+		return nil
+	}
+	return f.Validate()
+}
+
+// target is the property a builder creates filters for. Conditions on formula
+// properties are nested in a formula condition.
+type target struct {
+	property string
+	formula  bool
+}
+
+func (t target) build(set func(f *notionapi.PropertyFilter)) *notionapi.PropertyFilter {
+	f := &notionapi.PropertyFilter{Property: t.property}
+	if t.formula {
+		f.Formula = &notionapi.FormulaFilterCondition{}
+	}
+	set(f)
+	return f
+}
+
+// TextBuilder creates filters on title, rich_text, url, email and
+// phone_number properties. Comparisons with an empty string are sent as
+// they are, except for Equals and DoesNotEqual which check for empty values.
+type TextBuilder struct{ target }
+
+// Text starts a filter on a text property
+func Text(property string) TextBuilder {
+	return TextBuilder{target{property: property}}
+}
+
+func (b TextBuilder) condition(c *notionapi.TextFilterCondition) *notionapi.PropertyFilter {
+	return b.build(func(f *notionapi.PropertyFilter) {
+		if f.Formula != nil {
+			f.Formula.Text = c
+		} else {
+			f.Text = c
+		}
+	})
+}
+
+// Equals matches the value s. An empty s matches empty values.
+func (b TextBuilder) Equals(s string) *notionapi.PropertyFilter {
+	if s == "" {
+		return b.IsEmpty()
+	}
+	return b.condition(new(notionapi.TextFilterCondition).SetEquals(s))
+}
+
+// DoesNotEqual matches values other than s. An empty s matches values
+// which are not empty.
+func (b TextBuilder) DoesNotEqual(s string) *notionapi.PropertyFilter {
+	if s == "" {
+		return b.IsNotEmpty()
+	}
+	return b.condition(new(notionapi.TextFilterCondition).SetDoesNotEqual(s))
+}
+
+func (b TextBuilder) Contains(s string) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.TextFilterCondition).SetContains(s))
+}
+
+func (b TextBuilder) DoesNotContain(s string) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.TextFilterCondition).SetDoesNotContain(s))
+}
+
+func (b TextBuilder) StartsWith(s string) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.TextFilterCondition).SetStartsWith(s))
+}
+
+func (b TextBuilder) EndsWith(s string) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.TextFilterCondition).SetEndsWith(s))
+}
+
+func (b TextBuilder) IsEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.TextFilterCondition{IsEmpty: true})
+}
+
+func (b TextBuilder) IsNotEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.TextFilterCondition{IsNotEmpty: true})
+}
+
+// NumberBuilder creates filters on number properties
+type NumberBuilder struct{ target }
+
+// Number starts a filter on a number property
+func Number(property string) NumberBuilder {
+	return NumberBuilder{target{property: property}}
+}
+
+func (b NumberBuilder) condition(c *notionapi.NumberFilterCondition) *notionapi.PropertyFilter {
+	return b.build(func(f *notionapi.PropertyFilter) {
+		if f.Formula != nil {
+			f.Formula.Number = c
+		} else {
+			f.Number = c
+		}
+	})
+}
+
+func (b NumberBuilder) Equals(n float64) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.NumberFilterCondition).SetEquals(n))
+}
+
+func (b NumberBuilder) DoesNotEqual(n float64) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.NumberFilterCondition).SetDoesNotEqual(n))
+}
+
+func (b NumberBuilder) GreaterThan(n float64) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.NumberFilterCondition).SetGreaterThan(n))
+}
+
+func (b NumberBuilder) LessThan(n float64) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.NumberFilterCondition).SetLessThan(n))
+}
+
+func (b NumberBuilder) GreaterThanOrEqualTo(n float64) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.NumberFilterCondition).SetGreaterThanOrEqualTo(n))
+}
+
+func (b NumberBuilder) LessThanOrEqualTo(n float64) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.NumberFilterCondition).SetLessThanOrEqualTo(n))
+}
+
+func (b NumberBuilder) IsEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.NumberFilterCondition{IsEmpty: true})
+}
+
+func (b NumberBuilder) IsNotEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.NumberFilterCondition{IsNotEmpty: true})
+}
+
+// CheckboxBuilder creates filters on checkbox properties
+type CheckboxBuilder struct{ target }
+
+// Checkbox starts a filter on a checkbox property
+func Checkbox(property string) CheckboxBuilder {
+	return CheckboxBuilder{target{property: property}}
+}
+
+func (b CheckboxBuilder) condition(c *notionapi.CheckboxFilterCondition) *notionapi.PropertyFilter {
+	return b.build(func(f *notionapi.PropertyFilter) {
+		if f.Formula != nil {
+			f.Formula.Checkbox = c
+		} else {
+			f.Checkbox = c
+		}
+	})
+}
+
+func (b CheckboxBuilder) Equals(v bool) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.CheckboxFilterCondition).SetEquals(v))
+}
+
+func (b CheckboxBuilder) DoesNotEqual(v bool) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.CheckboxFilterCondition).SetDoesNotEqual(v))
+}
+
+// SelectBuilder creates filters on select properties
+type SelectBuilder struct{ target }
+
+// Select starts a filter on a select property
+func Select(property string) SelectBuilder {
+	return SelectBuilder{target{property: property}}
+}
+
+func (b SelectBuilder) condition(c *notionapi.SelectFilterCondition) *notionapi.PropertyFilter {
+	return b.build(func(f *notionapi.PropertyFilter) { f.Select = c })
+}
+
+// Equals matches the value option. An empty option matches empty values.
+func (b SelectBuilder) Equals(option string) *notionapi.PropertyFilter {
+	if option == "" {
+		return b.IsEmpty()
+	}
+	return b.condition(new(notionapi.SelectFilterCondition).SetEquals(option))
+}
+
+// DoesNotEqual matches values other than option. An empty option matches values
+// which are not empty.
+func (b SelectBuilder) DoesNotEqual(option string) *notionapi.PropertyFilter {
+	if option == "" {
+		return b.IsNotEmpty()
+	}
+	return b.condition(new(notionapi.SelectFilterCondition).SetDoesNotEqual(option))
+}
+
+func (b SelectBuilder) IsEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.SelectFilterCondition{IsEmpty: true})
+}
+
+func (b SelectBuilder) IsNotEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.SelectFilterCondition{IsNotEmpty: true})
+}
+
+// MultiSelectBuilder creates filters on multi_select properties
+type MultiSelectBuilder struct{ target }
+
+// MultiSelect starts a filter on a multi_select property
+func MultiSelect(property string) MultiSelectBuilder {
+	return MultiSelectBuilder{target{property: property}}
+}
+
+func (b MultiSelectBuilder) condition(c *notionapi.MultiSelectFilterCondition) *notionapi.PropertyFilter {
+	return b.build(func(f *notionapi.PropertyFilter) { f.MultiSelect = c })
+}
+
+func (b MultiSelectBuilder) Contains(option string) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.MultiSelectFilterCondition).SetContains(option))
+}
+
+func (b MultiSelectBuilder) DoesNotContain(option string) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.MultiSelectFilterCondition).SetDoesNotContain(option))
+}
+
+func (b MultiSelectBuilder) IsEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.MultiSelectFilterCondition{IsEmpty: true})
+}
+
+func (b MultiSelectBuilder) IsNotEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.MultiSelectFilterCondition{IsNotEmpty: true})
+}
+
+// DateBuilder creates filters on date, created_time and last_edited_time
+// properties. Dates created with notionapi.NewDate compare whole days, those
+// created with notionapi.NewDateTime compare times:
+//
+//	filter.Date("Due").OnOrAfter(notionapi.NewDate(t))
+//
+// Comparisons with a nil date check for empty values, like IsEmpty.
+type DateBuilder struct{ target }
+
+// Date starts a filter on a date property
+func Date(property string) DateBuilder {
+	return DateBuilder{target{property: property}}
+}
+
+func (b DateBuilder) condition(c notionapi.DateFilterCondition) *notionapi.PropertyFilter {
+	return b.build(func(f *notionapi.PropertyFilter) {
+		if f.Formula != nil {
+			f.Formula.Date = &c
+		} else {
+			f.Date = &c
+		}
+	})
+}
+
+// compare builds the condition set by set, or IsEmpty if d is nil
+func (b DateBuilder) compare(d *notionapi.DateObject, set func(c *notionapi.DateFilterCondition)) *notionapi.PropertyFilter {
+	if d == nil {
+		return b.IsEmpty()
+	}
+	var c notionapi.DateFilterCondition
+	set(&c)
+	return b.condition(c)
+}
+
+func (b DateBuilder) Equals(d *notionapi.DateObject) *notionapi.PropertyFilter {
+	return b.compare(d, func(c *notionapi.DateFilterCondition) { c.Equals = d })
+}
+
+func (b DateBuilder) Before(d *notionapi.DateObject) *notionapi.PropertyFilter {
+	return b.compare(d, func(c *notionapi.DateFilterCondition) { c.Before = d })
+}
+
+func (b DateBuilder) After(d *notionapi.DateObject) *notionapi.PropertyFilter {
+	return b.compare(d, func(c *notionapi.DateFilterCondition) { c.After = d })
+}
+
+func (b DateBuilder) OnOrBefore(d *notionapi.DateObject) *notionapi.PropertyFilter {
+	return b.compare(d, func(c *notionapi.DateFilterCondition) { c.OnOrBefore = d })
+}
+
+func (b DateBuilder) OnOrAfter(d *notionapi.DateObject) *notionapi.PropertyFilter {
+	return b.compare(d, func(c *notionapi.DateFilterCondition) { c.OnOrAfter = d })
+}
+
+func (b DateBuilder) PastWeek() *notionapi.PropertyFilter {
+	return b.condition(notionapi.DateFilterCondition{PastWeek: &struct{}{}})
+}
+
+func (b DateBuilder) PastMonth() *notionapi.PropertyFilter {
+	return b.condition(notionapi.DateFilterCondition{PastMonth: &struct{}{}})
+}
+
+func (b DateBuilder) PastYear() *notionapi.PropertyFilter {
+	return b.condition(notionapi.DateFilterCondition{PastYear: &struct{}{}})
+}
+
+func (b DateBuilder) NextWeek() *notionapi.PropertyFilter {
+	return b.condition(notionapi.DateFilterCondition{NextWeek: &struct{}{}})
+}
+
+func (b DateBuilder) NextMonth() *notionapi.PropertyFilter {
+	return b.condition(notionapi.DateFilterCondition{NextMonth: &struct{}{}})
+}
+
+func (b DateBuilder) NextYear() *notionapi.PropertyFilter {
+	return b.condition(notionapi.DateFilterCondition{NextYear: &struct{}{}})
+}
+
+func (b DateBuilder) IsEmpty() *notionapi.PropertyFilter {
+	return b.condition(notionapi.DateFilterCondition{IsEmpty: true})
+}
+
+func (b DateBuilder) IsNotEmpty() *notionapi.PropertyFilter {
+	return b.condition(notionapi.DateFilterCondition{IsNotEmpty: true})
+}
+
+// PeopleBuilder creates filters on people, created_by and last_edited_by
+// properties
+type PeopleBuilder struct{ target }
+
+// People starts a filter on a people property
+func People(property string) PeopleBuilder {
+	return PeopleBuilder{target{property: property}}
+}
+
+func (b PeopleBuilder) condition(c *notionapi.PeopleFilterCondition) *notionapi.PropertyFilter {
+	return b.build(func(f *notionapi.PropertyFilter) { f.People = c })
+}
+
+func (b PeopleBuilder) Contains(id notionapi.UserID) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.PeopleFilterCondition).SetContains(id.String()))
+}
+
+func (b PeopleBuilder) DoesNotContain(id notionapi.UserID) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.PeopleFilterCondition).SetDoesNotContain(id.String()))
+}
+
+func (b PeopleBuilder) IsEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.PeopleFilterCondition{IsEmpty: true})
+}
+
+func (b PeopleBuilder) IsNotEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.PeopleFilterCondition{IsNotEmpty: true})
+}
+
+// FilesBuilder creates filters on files properties
+type FilesBuilder struct{ target }
+
+// Files starts a filter on a files property
+func Files(property string) FilesBuilder {
+	return FilesBuilder{target{property: property}}
+}
+
+func (b FilesBuilder) condition(c notionapi.FilesFilterCondition) *notionapi.PropertyFilter {
+	return b.build(func(f *notionapi.PropertyFilter) { f.Files = &c })
+}
+
+func (b FilesBuilder) IsEmpty() *notionapi.PropertyFilter {
+	return b.condition(notionapi.FilesFilterCondition{IsEmpty: true})
+}
+
+func (b FilesBuilder) IsNotEmpty() *notionapi.PropertyFilter {
+	return b.condition(notionapi.FilesFilterCondition{IsNotEmpty: true})
+}
+
+// RelationBuilder creates filters on relation properties
+type RelationBuilder struct{ target }
+
+// Relation starts a filter on a relation property
+func Relation(property string) RelationBuilder {
+	return RelationBuilder{target{property: property}}
+}
+
+func (b RelationBuilder) condition(c *notionapi.RelationFilterCondition) *notionapi.PropertyFilter {
+	return b.build(func(f *notionapi.PropertyFilter) { f.Relation = c })
+}
+
+func (b RelationBuilder) Contains(id notionapi.PageID) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.RelationFilterCondition).SetContains(id.String()))
+}
+
+func (b RelationBuilder) DoesNotContain(id notionapi.PageID) *notionapi.PropertyFilter {
+	return b.condition(new(notionapi.RelationFilterCondition).SetDoesNotContain(id.String()))
+}
+
+func (b RelationBuilder) IsEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.RelationFilterCondition{IsEmpty: true})
+}
+
+func (b RelationBuilder) IsNotEmpty() *notionapi.PropertyFilter {
+	return b.condition(&notionapi.RelationFilterCondition{IsNotEmpty: true})
+}
+
+// FormulaBuilder creates filters on formula properties. The condition depends
+// on the type of the formula result:
+//
+//	filter.Formula("Score").Number().GreaterThan(10)
+type FormulaBuilder struct{ property string }
+
+// Formula starts a filter on a formula property
+func Formula(property string) FormulaBuilder {
+	return FormulaBuilder{property: property}
+}
+
+func (b FormulaBuilder) target() target {
+	return target{property: b.property, formula: true}
+}
+
+// Text filters formulas returning a string
+func (b FormulaBuilder) Text() TextBuilder {
+	return TextBuilder{b.target()}
+}
+
+// Number filters formulas returning a number
+func (b FormulaBuilder) Number() NumberBuilder {
+	return NumberBuilder{b.target()}
+}
+
+// Checkbox filters formulas returning a boolean
+func (b FormulaBuilder) Checkbox() CheckboxBuilder {
+	return CheckboxBuilder{b.target()}
+}
+
+// Date filters formulas returning a date
+func (b FormulaBuilder) Date() DateBuilder {
+	return DateBuilder{b.target()}
+}
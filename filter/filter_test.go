@@ -0,0 +1,165 @@
+package filter_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"github.com/jomei/notionapi/filter"
+)
+
+func TestBuilder(t *testing.T) {
+	due := time.Date(2021, 5, 10, 2, 43, 42, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter notionapi.Filter
+		want   string
+	}{
+		{
+			name:   "text",
+			filter: filter.Text("Name").Contains("report"),
+			want:   `{"property":"Name","text":{"contains":"report"}}`,
+		},
+		{
+			name:   "text equals empty",
+			filter: filter.Text("Name").Equals(""),
+			want:   `{"property":"Name","text":{"is_empty":true}}`,
+		},
+		{
+			name:   "text does not equal empty",
+			filter: filter.Formula("Label").Text().DoesNotEqual(""),
+			want:   `{"property":"Label","formula":{"text":{"is_not_empty":true}}}`,
+		},
+		{
+			name:   "text contains empty",
+			filter: filter.Text("Name").Contains(""),
+			want:   `{"property":"Name","text":{"contains":""}}`,
+		},
+		{
+			name:   "select equals empty",
+			filter: filter.Select("Status").Equals(""),
+			want:   `{"property":"Status","select":{"is_empty":true}}`,
+		},
+		{
+			name:   "select does not equal empty",
+			filter: filter.Select("Status").DoesNotEqual(""),
+			want:   `{"property":"Status","select":{"is_not_empty":true}}`,
+		},
+		{
+			name:   "number",
+			filter: filter.Number("Priority").GreaterThan(2),
+			want:   `{"property":"Priority","number":{"greater_than":2}}`,
+		},
+		{
+			name:   "number equals zero",
+			filter: filter.Number("Priority").Equals(0),
+			want:   `{"property":"Priority","number":{"equals":0}}`,
+		},
+		{
+			name:   "checkbox equals false",
+			filter: filter.Checkbox("Done").Equals(false),
+			want:   `{"property":"Done","checkbox":{"equals":false}}`,
+		},
+		{
+			name:   "date",
+			filter: filter.Date("Due").OnOrAfter(notionapi.NewDateTime(due)),
+			want:   `{"property":"Due","date":{"on_or_after":"2021-05-10T02:43:42Z"}}`,
+		},
+		{
+			name:   "date only",
+			filter: filter.Date("Due").Before(notionapi.NewDate(due)),
+			want:   `{"property":"Due","date":{"before":"2021-05-10"}}`,
+		},
+		{
+			name:   "date equals nil",
+			filter: filter.Date("Due").Equals(nil),
+			want:   `{"property":"Due","date":{"is_empty":true}}`,
+		},
+		{
+			name:   "formula date after nil",
+			filter: filter.Formula("Next").Date().After(nil),
+			want:   `{"property":"Next","formula":{"date":{"is_empty":true}}}`,
+		},
+		{
+			name:   "relative date",
+			filter: filter.Date("Due").NextWeek(),
+			want:   `{"property":"Due","date":{"next_week":{}}}`,
+		},
+		{
+			name:   "people",
+			filter: filter.People("Owner").Contains("some_user_id"),
+			want:   `{"property":"Owner","people":{"contains":"some_user_id"}}`,
+		},
+		{
+			name:   "formula",
+			filter: filter.Formula("Score").Text().StartsWith("A"),
+			want:   `{"property":"Score","formula":{"text":{"starts_with":"A"}}}`,
+		},
+		{
+			name: "nested compound",
+			filter: filter.And(
+				filter.Text("Name").Contains("x"),
+				filter.Or(
+					filter.Select("Status").Equals("Done"),
+					filter.MultiSelect("Tags").Contains("urgent"),
+				),
+			),
+			want: `{"and":[{"property":"Name","text":{"contains":"x"}},` +
+				`{"or":[{"property":"Status","select":{"equals":"Done"}},{"property":"Tags","multi_select":{"contains":"urgent"}}]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := filter.Validate(tt.filter); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			got, err := json.Marshal(tt.filter)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter notionapi.Filter
+	}{
+		{
+			name:   "no condition",
+			filter: &notionapi.PropertyFilter{Property: "Name"},
+		},
+		{
+			name: "two conditions",
+			filter: &notionapi.PropertyFilter{
+				Property: "Name",
+				Text:     &notionapi.TextFilterCondition{Contains: "x"},
+				Select:   &notionapi.SelectFilterCondition{Equals: "x"},
+			},
+		},
+		{
+			name:   "formula without condition",
+			filter: &notionapi.PropertyFilter{Property: "Score", Formula: &notionapi.FormulaFilterCondition{}},
+		},
+		{
+			name:   "empty compound",
+			filter: filter.Or(),
+		},
+		{
+			name:   "invalid nested filter",
+			filter: filter.And(filter.Text("Name").IsEmpty(), filter.Or(&notionapi.PropertyFilter{Property: "Name"})),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := filter.Validate(tt.filter); err == nil {
+				t.Error("Validate() error = nil, want error")
+			}
+		})
+	}
+}
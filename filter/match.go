@@ -0,0 +1,317 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// now is replaced in tests to evaluate relative date conditions
+var now = time.Now
+
+// Match reports whether page matches f the way a database query would. It
+// fails if f refers to a property the page does not have, or uses a
+// condition that does not fit the type of the property. A nil filter matches
+// every page.
+//
+// Text comparisons other than equals ignore case. Negated conditions like
+// does_not_equal or does_not_contain match empty values. Relative date
+// conditions such as past_week are evaluated against the current time.
+func Match(page *notionapi.Page, f notionapi.Filter) (bool, error) {
+	switch f := f.(type) {
+	case nil:
+		return true, nil
+	case *notionapi.PropertyFilter:
+		return matchProperty(page, f)
+	case notionapi.AndCompoundFilter:
+		return matchAll(page, f)
+	case notionapi.OrCompoundFilter:
+		return matchAny(page, f)
+	case notionapi.CompoundFilter:
+		if err := f.Validate(); err != nil {
+			return false, err
+		}
+		for operator, filters := range f {
+			compound := make([]notionapi.Filter, len(filters))
+			for i := range filters {
+				compound[i] = &filters[i]
+			}
+			if operator == notionapi.FilterOperatorAND {
+				return matchAll(page, compound)
+			}
+			return matchAny(page, compound)
+		}
+	}
+	return false, fmt.Errorf("filter: unsupported filter %T", f)
+}
+
+func matchAll(page *notionapi.Page, filters []notionapi.Filter) (bool, error) {
+	for _, f := range filters {
+		ok, err := Match(page, f)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchAny(page *notionapi.Page, filters []notionapi.Filter) (bool, error) {
+	for _, f := range filters {
+		ok, err := Match(page, f)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+func matchProperty(page *notionapi.Page, f *notionapi.PropertyFilter) (bool, error) {
+	if err := f.Validate(); err != nil {
+		return false, err
+	}
+	p, ok := page.Properties[f.Property]
+	if !ok {
+		return false, fmt.Errorf("filter: page %s has no property %q", page.ID, f.Property)
+	}
+	v, err := valueOf(p)
+	if err != nil {
+		return false, fmt.Errorf("filter: property %q: %w", f.Property, err)
+	}
+
+	var kind valueKind
+	var match func(value) bool
+	switch {
+	case f.Text != nil:
+		kind, match = kindText, textMatcher(f.Text)
+	case f.Number != nil:
+		kind, match = kindNumber, numberMatcher(f.Number)
+	case f.Checkbox != nil:
+		kind, match = kindCheckbox, checkboxMatcher(f.Checkbox)
+	case f.Select != nil:
+		kind, match = kindSelect, selectMatcher(f.Select)
+	case f.MultiSelect != nil:
+		kind, match = kindMultiSelect, multiSelectMatcher(f.MultiSelect)
+	case f.Date != nil:
+		kind, match = kindDate, dateMatcher(f.Date)
+	case f.People != nil:
+		kind, match = kindPeople, containsMatcher(f.People, f.People.Contains, f.People.DoesNotContain, f.People.IsEmpty)
+	case f.Files != nil:
+		kind, match = kindFiles, emptyMatcher(f.Files.IsEmpty)
+	case f.Relation != nil:
+		kind, match = kindRelation, containsMatcher(f.Relation, f.Relation.Contains, f.Relation.DoesNotContain, f.Relation.IsEmpty)
+	case f.Formula != nil:
+		if v.kind != kindFormula {
+			return false, fmt.Errorf("filter: can not use a formula condition on %s property %q", v.kind, f.Property)
+		}
+		return matchFormula(f.Property, *v.formula, f.Formula)
+	}
+	if v.kind != kind {
+		return false, fmt.Errorf("filter: can not use a %s condition on %s property %q", kind, v.kind, f.Property)
+	}
+	return match(v), nil
+}
+
+func matchFormula(property string, v value, c *notionapi.FormulaFilterCondition) (bool, error) {
+	var kind valueKind
+	var match func(value) bool
+	switch {
+	case c.Text != nil:
+		kind, match = kindText, textMatcher(c.Text)
+	case c.Number != nil:
+		kind, match = kindNumber, numberMatcher(c.Number)
+	case c.Checkbox != nil:
+		kind, match = kindCheckbox, checkboxMatcher(c.Checkbox)
+	case c.Date != nil:
+		kind, match = kindDate, dateMatcher(c.Date)
+	}
+	if v.kind != kind {
+		return false, fmt.Errorf("filter: can not use a %s condition on formula %q returning %s", kind, property, v.kind)
+	}
+	return match(v), nil
+}
+
+func emptyMatcher(isEmpty bool) func(value) bool {
+	return func(v value) bool {
+		return v.empty() == isEmpty
+	}
+}
+
+func textMatcher(c *notionapi.TextFilterCondition) func(value) bool {
+	return func(v value) bool {
+		text := strings.ToLower(v.text)
+		switch {
+		case c.Has(notionapi.ConditionEquals):
+			return v.text == c.Equals
+		case c.Has(notionapi.ConditionDoesNotEqual):
+			return v.text != c.DoesNotEqual
+		case c.Has(notionapi.ConditionContains):
+			return strings.Contains(text, strings.ToLower(c.Contains))
+		case c.Has(notionapi.ConditionDoesNotContain):
+			return !strings.Contains(text, strings.ToLower(c.DoesNotContain))
+		case c.Has(notionapi.ConditionDoesStartsWith):
+			return strings.HasPrefix(text, strings.ToLower(c.StartsWith))
+		case c.Has(notionapi.ConditionDoesEndsWith):
+			return strings.HasSuffix(text, strings.ToLower(c.EndsWith))
+		}
+		return v.empty() == c.IsEmpty
+	}
+}
+
+func numberMatcher(c *notionapi.NumberFilterCondition) func(value) bool {
+	return func(v value) bool {
+		if c.Has(notionapi.ConditionDoesNotEqual) {
+			return v.number == nil || *v.number != c.DoesNotEqual
+		}
+		if v.number == nil {
+			return c.IsEmpty
+		}
+		n := *v.number
+		switch {
+		case c.Has(notionapi.ConditionEquals):
+			return n == c.Equals
+		case c.Has(notionapi.ConditionGreaterThan):
+			return n > c.GreaterThan
+		case c.Has(notionapi.ConditionLessThan):
+			return n < c.LessThan
+		case c.Has(notionapi.ConditionGreaterThanOrEqualTo):
+			return n >= c.GreaterThanOrEqualTo
+		case c.Has(notionapi.ConditionLessThanOrEqualTo):
+			return n <= c.LessThanOrEqualTo
+		}
+		return c.IsNotEmpty
+	}
+}
+
+func checkboxMatcher(c *notionapi.CheckboxFilterCondition) func(value) bool {
+	return func(v value) bool {
+		if c.Has(notionapi.ConditionEquals) {
+			return v.checkbox == c.Equals
+		}
+		return v.checkbox != c.DoesNotEqual
+	}
+}
+
+func selectMatcher(c *notionapi.SelectFilterCondition) func(value) bool {
+	return func(v value) bool {
+		switch {
+		case c.Has(notionapi.ConditionEquals):
+			return contains(v.names, c.Equals)
+		case c.Has(notionapi.ConditionDoesNotEqual):
+			return !contains(v.names, c.DoesNotEqual)
+		}
+		return v.empty() == c.IsEmpty
+	}
+}
+
+func multiSelectMatcher(c *notionapi.MultiSelectFilterCondition) func(value) bool {
+	return containsMatcher(c, c.Contains, c.DoesNotContain, c.IsEmpty)
+}
+
+// comparer tells which comparison of a condition is sent
+type comparer interface {
+	Has(condition notionapi.Condition) bool
+}
+
+// containsMatcher matches list values like multi_select, people or relation
+func containsMatcher(c comparer, contained, notContained string, isEmpty bool) func(value) bool {
+	return func(v value) bool {
+		list := v.ids
+		if v.kind == kindMultiSelect {
+			list = v.names
+		}
+		switch {
+		case c.Has(notionapi.ConditionContains):
+			return contains(list, contained)
+		case c.Has(notionapi.ConditionDoesNotContain):
+			return !contains(list, notContained)
+		}
+		return v.empty() == isEmpty
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// dateMatcher compares dates. Date-only filter dates compare whole days,
+// ranges match if any day of the range matches.
+func dateMatcher(c *notionapi.DateFilterCondition) func(value) bool {
+	return func(v value) bool {
+		if v.start == nil {
+			return c.IsEmpty
+		}
+		start, end := *v.start, *v.start
+		if v.end != nil {
+			end = *v.end
+		}
+
+		switch {
+		case c.Equals != nil:
+			t, dateOnly := compared(c.Equals)
+			if dateOnly {
+				return !day(end).Before(day(t)) && !day(start).After(day(t))
+			}
+			return !end.Before(t) && !start.After(t)
+		case c.Before != nil:
+			t, dateOnly := compared(c.Before)
+			if dateOnly {
+				return day(start).Before(day(t))
+			}
+			return start.Before(t)
+		case c.After != nil:
+			t, dateOnly := compared(c.After)
+			if dateOnly {
+				return day(end).After(day(t))
+			}
+			return end.After(t)
+		case c.OnOrBefore != nil:
+			t, dateOnly := compared(c.OnOrBefore)
+			if dateOnly {
+				return !day(start).After(day(t))
+			}
+			return !start.After(t)
+		case c.OnOrAfter != nil:
+			t, dateOnly := compared(c.OnOrAfter)
+			if dateOnly {
+				return !day(end).Before(day(t))
+			}
+			return !end.Before(t)
+		case c.PastWeek != nil:
+			return overlaps(start, end, now().AddDate(0, 0, -7), now())
+		case c.PastMonth != nil:
+			return overlaps(start, end, now().AddDate(0, -1, 0), now())
+		case c.PastYear != nil:
+			return overlaps(start, end, now().AddDate(-1, 0, 0), now())
+		case c.NextWeek != nil:
+			return overlaps(start, end, now(), now().AddDate(0, 0, 7))
+		case c.NextMonth != nil:
+			return overlaps(start, end, now(), now().AddDate(0, 1, 0))
+		case c.NextYear != nil:
+			return overlaps(start, end, now(), now().AddDate(1, 0, 0))
+		}
+		return c.IsNotEmpty
+	}
+}
+
+// compared returns the start of a filter date and whether it is date-only
+func compared(d *notionapi.DateObject) (time.Time, bool) {
+	return time.Time(*d.Start), d.DateOnly
+}
+
+// day returns the calendar day of t as midnight UTC, so that days of
+// different time zones compare
+func day(t time.Time) time.Time {
+	year, month, d := t.Date()
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func overlaps(start, end, from, to time.Time) bool {
+	return !end.Before(from) && !start.After(to)
+}
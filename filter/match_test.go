@@ -0,0 +1,230 @@
+package filter_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"github.com/jomei/notionapi/filter"
+)
+
+func loadPages(t *testing.T) []notionapi.Page {
+	t.Helper()
+	data, err := ioutil.ReadFile("testdata/query.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res notionapi.DatabaseQueryResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal(err)
+	}
+	return res.Results
+}
+
+func pageIDs(pages []notionapi.Page) []notionapi.ObjectID {
+	ids := make([]notionapi.ObjectID, len(pages))
+	for i, p := range pages {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func TestMatch(t *testing.T) {
+	defer filter.SetNow(time.Date(2021, 5, 30, 0, 0, 0, 0, time.UTC))()
+	pages := loadPages(t)
+	day := func(s string) *notionapi.DateObject {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return notionapi.NewDate(d)
+	}
+
+	tests := []struct {
+		name   string
+		filter notionapi.Filter
+		want   []notionapi.ObjectID
+	}{
+		{"nil filter", nil, []notionapi.ObjectID{"page_1", "page_2", "page_3"}},
+		{"title equals", filter.Text("Name").Equals("Plan release"), []notionapi.ObjectID{"page_3"}},
+		{"title equals is case sensitive", filter.Text("Name").Equals("plan release"), nil},
+		{"title does not equal", filter.Text("Name").DoesNotEqual("Plan release"), []notionapi.ObjectID{"page_1", "page_2"}},
+		{"title contains", filter.Text("Name").Contains("RE"), []notionapi.ObjectID{"page_1", "page_2", "page_3"}},
+		{"title does not contain", filter.Text("Name").DoesNotContain("report"), []notionapi.ObjectID{"page_2", "page_3"}},
+		{"title starts with", filter.Text("Name").StartsWith("review"), []notionapi.ObjectID{"page_2"}},
+		{"title ends with", filter.Text("Name").EndsWith("Release"), []notionapi.ObjectID{"page_3"}},
+		{"url is empty", filter.Text("Website").IsEmpty(), []notionapi.ObjectID{"page_2", "page_3"}},
+		{"url is not empty", filter.Text("Website").IsNotEmpty(), []notionapi.ObjectID{"page_1"}},
+		{"number equals zero", filter.Number("Priority").Equals(0), []notionapi.ObjectID{"page_2"}},
+		{"number does not equal", filter.Number("Priority").DoesNotEqual(3), []notionapi.ObjectID{"page_2", "page_3"}},
+		{"number greater than", filter.Number("Priority").GreaterThan(0), []notionapi.ObjectID{"page_1"}},
+		{"number less than", filter.Number("Priority").LessThan(3), []notionapi.ObjectID{"page_2"}},
+		{"number greater than or equal to", filter.Number("Priority").GreaterThanOrEqualTo(0), []notionapi.ObjectID{"page_1", "page_2"}},
+		{"number less than or equal to", filter.Number("Priority").LessThanOrEqualTo(3), []notionapi.ObjectID{"page_1", "page_2"}},
+		{"number is empty", filter.Number("Priority").IsEmpty(), []notionapi.ObjectID{"page_3"}},
+		{"number is not empty", filter.Number("Priority").IsNotEmpty(), []notionapi.ObjectID{"page_1", "page_2"}},
+		{"checkbox equals false", filter.Checkbox("Done").Equals(false), []notionapi.ObjectID{"page_2", "page_3"}},
+		{"checkbox does not equal false", filter.Checkbox("Done").DoesNotEqual(false), []notionapi.ObjectID{"page_1"}},
+		{"select equals", filter.Select("Status").Equals("Done"), []notionapi.ObjectID{"page_1"}},
+		{"select does not equal", filter.Select("Status").DoesNotEqual("Done"), []notionapi.ObjectID{"page_2", "page_3"}},
+		{"select is empty", filter.Select("Status").IsEmpty(), []notionapi.ObjectID{"page_2"}},
+		{"multi_select contains", filter.MultiSelect("Tags").Contains("urgent"), []notionapi.ObjectID{"page_1", "page_3"}},
+		{"multi_select does not contain", filter.MultiSelect("Tags").DoesNotContain("docs"), []notionapi.ObjectID{"page_2", "page_3"}},
+		{"multi_select is not empty", filter.MultiSelect("Tags").IsNotEmpty(), []notionapi.ObjectID{"page_1", "page_3"}},
+		{"date equals day", filter.Date("Due").Equals(day("2021-06-01")), []notionapi.ObjectID{"page_1", "page_2"}},
+		{"date before", filter.Date("Due").Before(day("2021-06-01")), []notionapi.ObjectID{"page_2"}},
+		{"date after", filter.Date("Due").After(day("2021-06-01")), []notionapi.ObjectID{"page_2"}},
+		{"date on or before", filter.Date("Due").OnOrBefore(day("2021-05-28")), []notionapi.ObjectID{"page_2"}},
+		{"date on or after", filter.Date("Due").OnOrAfter(day("2021-06-01")), []notionapi.ObjectID{"page_1", "page_2"}},
+		{"date past week", filter.Date("Due").PastWeek(), []notionapi.ObjectID{"page_2"}},
+		{"date next week", filter.Date("Due").NextWeek(), []notionapi.ObjectID{"page_1", "page_2"}},
+		{"date past month", filter.Date("Due").PastMonth(), []notionapi.ObjectID{"page_2"}},
+		{"date next year", filter.Date("Due").NextYear(), []notionapi.ObjectID{"page_1", "page_2"}},
+		{"date is empty", filter.Date("Due").IsEmpty(), []notionapi.ObjectID{"page_3"}},
+		{"people contains", filter.People("Owners").Contains("user_2"), []notionapi.ObjectID{"page_3"}},
+		{"people is empty", filter.People("Owners").IsEmpty(), []notionapi.ObjectID{"page_2"}},
+		{"files is not empty", filter.Files("Attachments").IsNotEmpty(), []notionapi.ObjectID{"page_1"}},
+		{"relation contains", filter.Relation("Parents").Contains("page_3"), []notionapi.ObjectID{"page_1"}},
+		{"relation does not contain", filter.Relation("Parents").DoesNotContain("page_3"), []notionapi.ObjectID{"page_2", "page_3"}},
+		{"formula number", filter.Formula("Score").Number().GreaterThanOrEqualTo(0), []notionapi.ObjectID{"page_1", "page_2"}},
+		{"formula is empty", filter.Formula("Score").Number().IsEmpty(), []notionapi.ObjectID{"page_3"}},
+		{
+			"nested compound",
+			filter.And(
+				filter.Checkbox("Done").Equals(false),
+				filter.Or(filter.Number("Priority").IsEmpty(), filter.Text("Name").Contains("notes")),
+			),
+			[]notionapi.ObjectID{"page_2", "page_3"},
+		},
+		{
+			"legacy compound",
+			notionapi.CompoundFilter{notionapi.FilterOperatorOR: []notionapi.PropertyFilter{
+				{Property: "Status", Select: &notionapi.SelectFilterCondition{Equals: "Done"}},
+				{Property: "Status", Select: &notionapi.SelectFilterCondition{Equals: "In progress"}},
+			}},
+			[]notionapi.ObjectID{"page_1", "page_3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []notionapi.ObjectID
+			for i := range pages {
+				ok, err := filter.Match(&pages[i], tt.filter)
+				if err != nil {
+					t.Fatalf("Match() error = %v", err)
+				}
+				if ok {
+					got = append(got, pages[i].ID)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Match() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("fails", func(t *testing.T) {
+		for _, f := range []notionapi.Filter{
+			filter.Text("Missing").IsEmpty(),
+			filter.Number("Name").Equals(1),
+			filter.Formula("Score").Text().IsEmpty(),
+			filter.Formula("Name").Text().IsEmpty(),
+			&notionapi.PropertyFilter{Property: "Name"},
+		} {
+			if _, err := filter.Match(&pages[0], f); err == nil {
+				t.Errorf("Match(%+v) error = nil, want error", f)
+			}
+		}
+	})
+}
+
+func TestSortPages(t *testing.T) {
+	tests := []struct {
+		name  string
+		sorts []notionapi.SortObject
+		want  []notionapi.ObjectID
+	}{
+		{
+			name:  "no sorts keep the order",
+			sorts: nil,
+			want:  []notionapi.ObjectID{"page_1", "page_2", "page_3"},
+		},
+		{
+			name:  "text ignores case",
+			sorts: []notionapi.SortObject{{Property: "Name", Direction: notionapi.SortOrderASC}},
+			want:  []notionapi.ObjectID{"page_3", "page_2", "page_1"},
+		},
+		{
+			name:  "empty values come last",
+			sorts: []notionapi.SortObject{{Property: "Priority", Direction: notionapi.SortOrderDESC}},
+			want:  []notionapi.ObjectID{"page_1", "page_2", "page_3"},
+		},
+		{
+			name:  "empty values come last ascending",
+			sorts: []notionapi.SortObject{{Property: "Priority", Direction: notionapi.SortOrderASC}},
+			want:  []notionapi.ObjectID{"page_2", "page_1", "page_3"},
+		},
+		{
+			name: "later sorts break ties",
+			sorts: []notionapi.SortObject{
+				{Property: "Done", Direction: notionapi.SortOrderASC},
+				{Property: "Due", Direction: notionapi.SortOrderASC},
+			},
+			want: []notionapi.ObjectID{"page_2", "page_3", "page_1"},
+		},
+		{
+			name:  "timestamp",
+			sorts: []notionapi.SortObject{{Timestamp: notionapi.TimestampCreated, Direction: notionapi.SortOrderDESC}},
+			want:  []notionapi.ObjectID{"page_2", "page_3", "page_1"},
+		},
+		{
+			name:  "formula",
+			sorts: []notionapi.SortObject{{Property: "Score", Direction: notionapi.SortOrderASC}},
+			want:  []notionapi.ObjectID{"page_2", "page_1", "page_3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := loadPages(t)
+			filter.SortPages(pages, tt.sorts)
+			if got := pageIDs(pages); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortPages() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortPages_Kinds(t *testing.T) {
+	score := func(id notionapi.ObjectID, f notionapi.FormulaValue) notionapi.Page {
+		return notionapi.Page{ID: id, Properties: notionapi.Properties{
+			"Score": notionapi.FormulaProperty{Type: notionapi.PropertyTypeFormula, Formula: f},
+		}}
+	}
+	text := func(s string) notionapi.FormulaValue {
+		return notionapi.FormulaValue{Type: notionapi.FormulaTypeString, String: &s}
+	}
+	number := func(n float64) notionapi.FormulaValue {
+		return notionapi.FormulaValue{Type: notionapi.FormulaTypeNumber, Number: &n}
+	}
+	sorts := map[notionapi.SortOrder][]notionapi.ObjectID{
+		notionapi.SortOrderASC:  {"text_a", "text_b", "number_1", "number_2"},
+		notionapi.SortOrderDESC: {"text_b", "text_a", "number_2", "number_1"},
+	}
+	for direction, want := range sorts {
+		t.Run(string(direction), func(t *testing.T) {
+			pages := []notionapi.Page{
+				score("number_2", number(2)),
+				score("text_b", text("b")),
+				score("number_1", number(1)),
+				score("text_a", text("a")),
+			}
+			filter.SortPages(pages, []notionapi.SortObject{{Property: "Score", Direction: direction}})
+			if got := pageIDs(pages); !reflect.DeepEqual(got, want) {
+				t.Errorf("SortPages() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package filter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// SortPages orders pages in place like a database query with sorts would.
+// Earlier sorts take precedence, pages comparing equal keep their order.
+// Empty values and properties that can not be sorted come last in both
+// directions. Select options are ordered by name, as the order of the options
+// is part of the database schema. Values of different kinds, like the results
+// of a formula returning different types, are grouped by kind in the order
+// text, number, checkbox, select, multi_select, date, people, relation and
+// files.
+func SortPages(pages []notionapi.Page, sorts []notionapi.SortObject) {
+	if len(sorts) == 0 {
+		return
+	}
+	keys := make([][]value, len(pages))
+	for i := range pages {
+		keys[i] = make([]value, len(sorts))
+		for j, s := range sorts {
+			keys[i][j] = sortValue(&pages[i], s)
+		}
+	}
+
+	indexes := make([]int, len(pages))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		for j, s := range sorts {
+			c := compareValues(keys[indexes[a]][j], keys[indexes[b]][j], s.Direction == notionapi.SortOrderDESC)
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	sorted := make([]notionapi.Page, len(pages))
+	for i, index := range indexes {
+		sorted[i] = pages[index]
+	}
+	copy(pages, sorted)
+}
+
+func sortValue(page *notionapi.Page, s notionapi.SortObject) value {
+	switch s.Timestamp {
+	case notionapi.TimestampCreated:
+		return value{kind: kindDate, start: &page.CreatedTime}
+	case notionapi.TimestampLastEdited:
+		return value{kind: kindDate, start: &page.LastEditedTime}
+	}
+	p, ok := page.Properties[s.Property]
+	if !ok {
+		return value{}
+	}
+	v, err := valueOf(p)
+	if err != nil {
+		return value{}
+	}
+	if v.kind == kindFormula {
+		if v.formula == nil {
+			return value{}
+		}
+		return *v.formula
+	}
+	return v
+}
+
+// compareValues returns a negative number if a sorts before b. Empty values
+// sort last regardless of the direction.
+func compareValues(a, b value, descending bool) int {
+	aEmpty, bEmpty := a.kind == "" || a.empty(), b.kind == "" || b.empty()
+	switch {
+	case aEmpty && bEmpty:
+		return 0
+	case aEmpty:
+		return 1
+	case bEmpty:
+		return -1
+	case a.kind != b.kind:
+		return kindRank[a.kind] - kindRank[b.kind]
+	}
+
+	c := 0
+	switch a.kind {
+	case kindText:
+		c = compareStrings(strings.ToLower(a.text), strings.ToLower(b.text))
+	case kindNumber:
+		c = compareFloats(*a.number, *b.number)
+	case kindCheckbox:
+		c = compareBools(a.checkbox, b.checkbox)
+	case kindSelect, kindMultiSelect:
+		c = compareStrings(strings.Join(a.names, ","), strings.Join(b.names, ","))
+	case kindDate:
+		switch {
+		case a.start.Before(*b.start):
+			c = -1
+		case a.start.After(*b.start):
+			c = 1
+		}
+	case kindPeople, kindRelation:
+		c = compareStrings(strings.Join(a.ids, ","), strings.Join(b.ids, ","))
+	case kindFiles:
+		c = a.files - b.files
+	}
+	if descending {
+		return -c
+	}
+	return c
+}
+
+// kindRank orders values of different kinds
+var kindRank = map[valueKind]int{
+	kindText:        1,
+	kindNumber:      2,
+	kindCheckbox:    3,
+	kindSelect:      4,
+	kindMultiSelect: 5,
+	kindDate:        6,
+	kindPeople:      7,
+	kindRelation:    8,
+	kindFiles:       9,
+}
+
+func compareStrings(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+	return 1
+}
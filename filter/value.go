@@ -0,0 +1,172 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// valueKind groups property types by the conditions they support
+type valueKind string
+
+const (
+	kindText        valueKind = "text"
+	kindNumber      valueKind = "number"
+	kindCheckbox    valueKind = "checkbox"
+	kindSelect      valueKind = "select"
+	kindMultiSelect valueKind = "multi_select"
+	kindDate        valueKind = "date"
+	kindPeople      valueKind = "people"
+	kindFiles       valueKind = "files"
+	kindRelation    valueKind = "relation"
+	kindFormula     valueKind = "formula"
+)
+
+// value is a property value normalized for matching and sorting
+type value struct {
+	kind valueKind
+
+	text     string
+	number   *float64
+	checkbox bool
+	// names are the selected options
+	names []string
+	start *time.Time
+	end   *time.Time
+	// ids of people or related pages
+	ids   []string
+	files int
+	// formula is the result of a formula
+	formula *value
+}
+
+func (v value) empty() bool {
+	switch v.kind {
+	case kindText:
+		return v.text == ""
+	case kindNumber:
+		return v.number == nil
+	case kindSelect, kindMultiSelect:
+		return len(v.names) == 0
+	case kindDate:
+		return v.start == nil
+	case kindPeople, kindRelation:
+		return len(v.ids) == 0
+	case kindFiles:
+		return v.files == 0
+	case kindFormula:
+		return v.formula == nil || v.formula.empty()
+	}
+	return false
+}
+
+// valueOf normalizes a page property
+func valueOf(p notionapi.Property) (value, error) {
+	if rv := reflect.ValueOf(p); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return value{}, fmt.Errorf("nil property")
+		}
+		p = rv.Elem().Interface().(notionapi.Property)
+	}
+
+	switch p := p.(type) {
+	case notionapi.TitleProperty:
+		return value{kind: kindText, text: p.Title.PlainText()}, nil
+	case notionapi.RichTextProperty:
+		return value{kind: kindText, text: p.RichText.PlainText()}, nil
+	case notionapi.URLProperty:
+		return value{kind: kindText, text: p.URL}, nil
+	case notionapi.EmailProperty:
+		return value{kind: kindText, text: p.Email}, nil
+	case notionapi.PhoneNumberProperty:
+		return value{kind: kindText, text: p.PhoneNumber}, nil
+	case notionapi.NumberProperty:
+		return value{kind: kindNumber, number: p.Number}, nil
+	case notionapi.CheckboxProperty:
+		return value{kind: kindCheckbox, checkbox: p.Checkbox}, nil
+	case notionapi.SelectProperty:
+		v := value{kind: kindSelect}
+		if p.Select != nil && p.Select.Name != "" {
+			v.names = []string{p.Select.Name}
+		}
+		return v, nil
+	case notionapi.MultiSelectProperty:
+		v := value{kind: kindMultiSelect}
+		for _, o := range p.MultiSelect {
+			v.names = append(v.names, o.Name)
+		}
+		return v, nil
+	case notionapi.DateProperty:
+		return dateValue(p.Date), nil
+	case notionapi.CreatedTimeProperty:
+		return timestampValue(p.CreatedTime), nil
+	case notionapi.LastEditedTimeProperty:
+		return timestampValue(p.LastEditedTime), nil
+	case notionapi.PeopleProperty:
+		v := value{kind: kindPeople}
+		for _, id := range p.UserIDs() {
+			v.ids = append(v.ids, id.String())
+		}
+		return v, nil
+	case notionapi.CreatedByProperty:
+		return value{kind: kindPeople, ids: []string{p.CreatedBy.ID.String()}}, nil
+	case notionapi.LastEditedByProperty:
+		return value{kind: kindPeople, ids: []string{p.LastEditedBy.ID.String()}}, nil
+	case notionapi.RelationProperty:
+		v := value{kind: kindRelation}
+		for _, id := range p.PageIDs() {
+			v.ids = append(v.ids, id.String())
+		}
+		return v, nil
+	case notionapi.FilesProperty:
+		return value{kind: kindFiles, files: len(p.Files)}, nil
+	case notionapi.FormulaProperty:
+		return formulaValue(p.Formula)
+	}
+	return value{}, fmt.Errorf("can not filter %s properties", p.GetType())
+}
+
+func formulaValue(f notionapi.FormulaValue) (value, error) {
+	var result value
+	switch f.Type {
+	case notionapi.FormulaTypeString:
+		s, _ := f.AsString()
+		result = value{kind: kindText, text: s}
+	case notionapi.FormulaTypeNumber:
+		result = value{kind: kindNumber, number: f.Number}
+	case notionapi.FormulaTypeBoolean:
+		b, _ := f.AsBool()
+		result = value{kind: kindCheckbox, checkbox: b}
+	case notionapi.FormulaTypeDate:
+		result = dateValue(f.Date)
+	default:
+		return value{}, fmt.Errorf("unsupported formula result %q", f.Type)
+	}
+	return value{kind: kindFormula, formula: &result}, nil
+}
+
+func dateValue(date *notionapi.DateObject) value {
+	v := value{kind: kindDate}
+	if date == nil {
+		return v
+	}
+	if date.Start != nil {
+		start := time.Time(*date.Start)
+		v.start = &start
+	}
+	if date.End != nil {
+		end := time.Time(*date.End)
+		v.end = &end
+	}
+	return v
+}
+
+func timestampValue(t time.Time) value {
+	v := value{kind: kindDate}
+	if !t.IsZero() {
+		v.start = &t
+	}
+	return v
+}
@@ -0,0 +1,154 @@
+package notionapi_test
+
+import (
+	"encoding/json"
+	"github.com/jomei/notionapi"
+	"testing"
+	"time"
+)
+
+func TestFilterCondition_MarshalJSON(t *testing.T) {
+	date := notionapi.NewDateTime(time.Date(2021, 5, 10, 2, 43, 42, 0, time.UTC))
+	day := notionapi.NewDate(time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name      string
+		condition interface{}
+		want      string
+	}{
+		{"text equals", notionapi.TextFilterCondition{Equals: "a"}, `{"equals":"a"}`},
+		{"text does not equal", notionapi.TextFilterCondition{DoesNotEqual: "a"}, `{"does_not_equal":"a"}`},
+		{"text contains", notionapi.TextFilterCondition{Contains: "a"}, `{"contains":"a"}`},
+		{"text does not contain", notionapi.TextFilterCondition{DoesNotContain: "a"}, `{"does_not_contain":"a"}`},
+		{"text starts with", notionapi.TextFilterCondition{StartsWith: "a"}, `{"starts_with":"a"}`},
+		{"text ends with", notionapi.TextFilterCondition{EndsWith: "a"}, `{"ends_with":"a"}`},
+		{"text is empty", notionapi.TextFilterCondition{IsEmpty: true}, `{"is_empty":true}`},
+		{"text is not empty", notionapi.TextFilterCondition{IsNotEmpty: true}, `{"is_not_empty":true}`},
+		{"text equals empty", new(notionapi.TextFilterCondition).SetEquals(""), `{"equals":""}`},
+		{"text starts with empty", new(notionapi.TextFilterCondition).SetStartsWith(""), `{"starts_with":""}`},
+		{"text without comparison", notionapi.TextFilterCondition{}, `{}`},
+		{"number equals zero", new(notionapi.NumberFilterCondition).SetEquals(0), `{"equals":0}`},
+		{"number does not equal zero", new(notionapi.NumberFilterCondition).SetDoesNotEqual(0), `{"does_not_equal":0}`},
+		{"number greater than", new(notionapi.NumberFilterCondition).SetGreaterThan(1.5), `{"greater_than":1.5}`},
+		{"number less than", new(notionapi.NumberFilterCondition).SetLessThan(-1), `{"less_than":-1}`},
+		{"number greater than or equal to zero", new(notionapi.NumberFilterCondition).SetGreaterThanOrEqualTo(0), `{"greater_than_or_equal_to":0}`},
+		{"number less than or equal to zero", new(notionapi.NumberFilterCondition).SetLessThanOrEqualTo(0), `{"less_than_or_equal_to":0}`},
+		{"number equals", notionapi.NumberFilterCondition{Equals: 2}, `{"equals":2}`},
+		{"number greater than or equal to", notionapi.NumberFilterCondition{GreaterThanOrEqualTo: 3}, `{"greater_than_or_equal_to":3}`},
+		{"number is empty", notionapi.NumberFilterCondition{IsEmpty: true}, `{"is_empty":true}`},
+		{"number is not empty", notionapi.NumberFilterCondition{IsNotEmpty: true}, `{"is_not_empty":true}`},
+		{"number without comparison", notionapi.NumberFilterCondition{}, `{}`},
+		{"checkbox equals false", new(notionapi.CheckboxFilterCondition).SetEquals(false), `{"equals":false}`},
+		{"checkbox equals true", new(notionapi.CheckboxFilterCondition).SetEquals(true), `{"equals":true}`},
+		{"checkbox does not equal false", new(notionapi.CheckboxFilterCondition).SetDoesNotEqual(false), `{"does_not_equal":false}`},
+		{"checkbox equals", notionapi.CheckboxFilterCondition{Equals: true}, `{"equals":true}`},
+		{"checkbox without comparison", notionapi.CheckboxFilterCondition{}, `{}`},
+		{"select equals", notionapi.SelectFilterCondition{Equals: "a"}, `{"equals":"a"}`},
+		{"select does not equal", notionapi.SelectFilterCondition{DoesNotEqual: "a"}, `{"does_not_equal":"a"}`},
+		{"select equals empty", new(notionapi.SelectFilterCondition).SetEquals(""), `{"equals":""}`},
+		{"select does not equal empty", new(notionapi.SelectFilterCondition).SetDoesNotEqual(""), `{"does_not_equal":""}`},
+		{"select is empty", notionapi.SelectFilterCondition{IsEmpty: true}, `{"is_empty":true}`},
+		{"select is not empty", notionapi.SelectFilterCondition{IsNotEmpty: true}, `{"is_not_empty":true}`},
+		{"multi_select contains", notionapi.MultiSelectFilterCondition{Contains: "a"}, `{"contains":"a"}`},
+		{"multi_select does not contain", notionapi.MultiSelectFilterCondition{DoesNotContain: "a"}, `{"does_not_contain":"a"}`},
+		{"multi_select contains empty", new(notionapi.MultiSelectFilterCondition).SetContains(""), `{"contains":""}`},
+		{"multi_select is empty", notionapi.MultiSelectFilterCondition{IsEmpty: true}, `{"is_empty":true}`},
+		{"date equals", notionapi.DateFilterCondition{Equals: date}, `{"equals":"2021-05-10T02:43:42Z"}`},
+		{"date equals day", notionapi.DateFilterCondition{Equals: day}, `{"equals":"2021-05-10"}`},
+		{"date before", notionapi.DateFilterCondition{Before: date}, `{"before":"2021-05-10T02:43:42Z"}`},
+		{"date after", notionapi.DateFilterCondition{After: date}, `{"after":"2021-05-10T02:43:42Z"}`},
+		{"date on or before", notionapi.DateFilterCondition{OnOrBefore: date}, `{"on_or_before":"2021-05-10T02:43:42Z"}`},
+		{"date on or after", notionapi.DateFilterCondition{OnOrAfter: date}, `{"on_or_after":"2021-05-10T02:43:42Z"}`},
+		{"date past week", notionapi.DateFilterCondition{PastWeek: &struct{}{}}, `{"past_week":{}}`},
+		{"date past month", notionapi.DateFilterCondition{PastMonth: &struct{}{}}, `{"past_month":{}}`},
+		{"date past year", notionapi.DateFilterCondition{PastYear: &struct{}{}}, `{"past_year":{}}`},
+		{"date next week", notionapi.DateFilterCondition{NextWeek: &struct{}{}}, `{"next_week":{}}`},
+		{"date next month", notionapi.DateFilterCondition{NextMonth: &struct{}{}}, `{"next_month":{}}`},
+		{"date next year", notionapi.DateFilterCondition{NextYear: &struct{}{}}, `{"next_year":{}}`},
+		{"date without comparison", notionapi.DateFilterCondition{}, `{}`},
+		{"date is empty", notionapi.DateFilterCondition{IsEmpty: true}, `{"is_empty":true}`},
+		{"people contains", notionapi.PeopleFilterCondition{Contains: "some_id"}, `{"contains":"some_id"}`},
+		{"people does not contain", notionapi.PeopleFilterCondition{DoesNotContain: "some_id"}, `{"does_not_contain":"some_id"}`},
+		{"people contains set", new(notionapi.PeopleFilterCondition).SetContains("some_id"), `{"contains":"some_id"}`},
+		{"people is empty", notionapi.PeopleFilterCondition{IsEmpty: true}, `{"is_empty":true}`},
+		{"files is empty", notionapi.FilesFilterCondition{IsEmpty: true}, `{"is_empty":true}`},
+		{"files is not empty", notionapi.FilesFilterCondition{IsNotEmpty: true}, `{"is_not_empty":true}`},
+		{"relation contains", notionapi.RelationFilterCondition{Contains: "some_id"}, `{"contains":"some_id"}`},
+		{"relation does not contain", notionapi.RelationFilterCondition{DoesNotContain: "some_id"}, `{"does_not_contain":"some_id"}`},
+		{"relation does not contain set", new(notionapi.RelationFilterCondition).SetDoesNotContain("some_id"), `{"does_not_contain":"some_id"}`},
+		{"relation is not empty", notionapi.RelationFilterCondition{IsNotEmpty: true}, `{"is_not_empty":true}`},
+		{
+			"formula number equals zero",
+			notionapi.FormulaFilterCondition{Number: new(notionapi.NumberFilterCondition).SetEquals(0)},
+			`{"number":{"equals":0}}`,
+		},
+		{
+			"formula checkbox equals false",
+			notionapi.FormulaFilterCondition{Checkbox: new(notionapi.CheckboxFilterCondition).SetEquals(false)},
+			`{"checkbox":{"equals":false}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.condition)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPropertyFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  notionapi.PropertyFilter
+		wantErr bool
+	}{
+		{
+			name:   "number equals zero",
+			filter: notionapi.PropertyFilter{Property: "P", Number: new(notionapi.NumberFilterCondition).SetEquals(0)},
+		},
+		{
+			name:   "checkbox equals false",
+			filter: notionapi.PropertyFilter{Property: "P", Checkbox: new(notionapi.CheckboxFilterCondition).SetEquals(false)},
+		},
+		{
+			name:    "number without comparison",
+			filter:  notionapi.PropertyFilter{Property: "P", Number: &notionapi.NumberFilterCondition{}},
+			wantErr: true,
+		},
+		{
+			name: "two comparisons",
+			filter: notionapi.PropertyFilter{Property: "P", Number: &notionapi.NumberFilterCondition{
+				GreaterThan: 1,
+				LessThan:    5,
+			}},
+			wantErr: true,
+		},
+		{
+			name:   "text equals empty",
+			filter: notionapi.PropertyFilter{Property: "P", Text: new(notionapi.TextFilterCondition).SetEquals("")},
+		},
+		{
+			name:    "empty text",
+			filter:  notionapi.PropertyFilter{Property: "P", Text: &notionapi.TextFilterCondition{}},
+			wantErr: true,
+		},
+		{
+			name: "formula without comparison",
+			filter: notionapi.PropertyFilter{Property: "P", Formula: &notionapi.FormulaFilterCondition{
+				Checkbox: &notionapi.CheckboxFilterCondition{},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
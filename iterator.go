@@ -0,0 +1,215 @@
+package notionapi
+
+import "context"
+
+// fetchFunc loads the batch starting at cursor and returns its size along
+// with the cursor of the following batch.
+type fetchFunc func(ctx context.Context, cursor Cursor) (n int, next Cursor, hasMore bool, err error)
+
+// iterator implements the cursor loop shared by all typed iterators
+type iterator struct {
+	ctx   context.Context
+	fetch fetchFunc
+
+	cursor  Cursor
+	next    Cursor
+	hasMore bool
+	fetched bool
+
+	n     int
+	index int
+	err   error
+}
+
+func newIterator(ctx context.Context, start Cursor, fetch fetchFunc) iterator {
+	return iterator{ctx: ctx, fetch: fetch, next: start, index: -1}
+}
+
+func (it *iterator) advance() bool {
+	for it.err == nil {
+		if it.index+1 < it.n {
+			it.index++
+			return true
+		}
+		if it.fetched && (!it.hasMore || it.next == "") {
+			return false
+		}
+
+		n, next, hasMore, err := it.fetch(it.ctx, it.next)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.cursor, it.next, it.hasMore = it.next, next, hasMore
+		it.fetched = true
+		it.n, it.index = n, -1
+	}
+	return false
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *iterator) Err() error {
+	return it.err
+}
+
+// Cursor returns the start cursor of the batch the current value belongs to.
+// Resuming from it never skips values, but may repeat values of that batch.
+func (it *iterator) Cursor() Cursor {
+	return it.cursor
+}
+
+func limitReached(limit, count int) bool {
+	return limit > 0 && count >= limit
+}
+
+// PageIterator iterates over the pages returned by DatabaseService.QueryAll
+type PageIterator struct {
+	iterator
+	items []Page
+}
+
+// Next advances to the next page. It returns false when there are no more
+// pages or an error occurred.
+func (it *PageIterator) Next() bool {
+	return it.advance()
+}
+
+// Value returns the current page
+func (it *PageIterator) Value() Page {
+	return it.items[it.index]
+}
+
+// Collect returns all remaining pages. When limit is positive it stops after
+// that many pages.
+func (it *PageIterator) Collect(limit int) ([]Page, error) {
+	var result []Page
+	for !limitReached(limit, len(result)) && it.Next() {
+		result = append(result, it.Value())
+	}
+	return result, it.Err()
+}
+
+// DatabaseIterator iterates over the databases returned by DatabaseService.ListAll
+type DatabaseIterator struct {
+	iterator
+	items []Database
+}
+
+// Next advances to the next database. It returns false when there are no
+// more databases or an error occurred.
+func (it *DatabaseIterator) Next() bool {
+	return it.advance()
+}
+
+// Value returns the current database
+func (it *DatabaseIterator) Value() Database {
+	return it.items[it.index]
+}
+
+// Collect returns all remaining databases. When limit is positive it stops
+// after that many databases.
+func (it *DatabaseIterator) Collect(limit int) ([]Database, error) {
+	var result []Database
+	for !limitReached(limit, len(result)) && it.Next() {
+		result = append(result, it.Value())
+	}
+	return result, it.Err()
+}
+
+// UserIterator iterates over the users returned by UserService.ListAll
+type UserIterator struct {
+	iterator
+	items []User
+}
+
+// Next advances to the next user. It returns false when there are no more
+// users or an error occurred.
+func (it *UserIterator) Next() bool {
+	return it.advance()
+}
+
+// Value returns the current user
+func (it *UserIterator) Value() User {
+	return it.items[it.index]
+}
+
+// Collect returns all remaining users. When limit is positive it stops after
+// that many users.
+func (it *UserIterator) Collect(limit int) ([]User, error) {
+	var result []User
+	for !limitReached(limit, len(result)) && it.Next() {
+		result = append(result, it.Value())
+	}
+	return result, it.Err()
+}
+
+// ObjectIterator iterates over the pages and databases returned by
+// SearchService.DoAll
+type ObjectIterator struct {
+	iterator
+	items []Object
+}
+
+// Next advances to the next object. It returns false when there are no more
+// objects or an error occurred.
+func (it *ObjectIterator) Next() bool {
+	return it.advance()
+}
+
+// Value returns the current object, either a *Page or a *Database
+func (it *ObjectIterator) Value() Object {
+	return it.items[it.index]
+}
+
+// Collect returns all remaining objects. When limit is positive it stops
+// after that many objects.
+func (it *ObjectIterator) Collect(limit int) ([]Object, error) {
+	var result []Object
+	for !limitReached(limit, len(result)) && it.Next() {
+		result = append(result, it.Value())
+	}
+	return result, it.Err()
+}
+
+// BlockIterator iterates over the blocks returned by BlockService.GetChildrenAll
+type BlockIterator struct {
+	iterator
+	items []Block
+}
+
+// Next advances to the next block. It returns false when there are no more
+// blocks or an error occurred.
+func (it *BlockIterator) Next() bool {
+	return it.advance()
+}
+
+// Value returns the current block
+func (it *BlockIterator) Value() Block {
+	return it.items[it.index]
+}
+
+// Collect returns all remaining blocks. When limit is positive it stops after
+// that many blocks.
+func (it *BlockIterator) Collect(limit int) ([]Block, error) {
+	var result []Block
+	for !limitReached(limit, len(result)) && it.Next() {
+		result = append(result, it.Value())
+	}
+	return result, it.Err()
+}
+
+// paginationFrom copies p with the start cursor replaced
+func paginationFrom(p *Pagination, cursor Cursor) *Pagination {
+	result := Pagination{StartCursor: cursor}
+	if p != nil {
+		result.PageSize = p.PageSize
+	}
+	return &result
+}
+
+func startCursor(p *Pagination) Cursor {
+	if p == nil {
+		return ""
+	}
+	return p.StartCursor
+}
@@ -0,0 +1,134 @@
+package notionapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jomei/notionapi"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// newPaginatedClient returns *http.Client which responds with firstPage unless
+// the request carries a start cursor, in which case it responds with nextPage
+func newPaginatedClient(t *testing.T, firstPage, nextPage string) *http.Client {
+	return newTestClient(func(req *http.Request) *http.Response {
+		cursor := req.URL.Query().Get("start_cursor")
+		if req.Body != nil {
+			var body struct {
+				StartCursor string `json:"start_cursor"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			cursor = body.StartCursor
+		}
+
+		file := firstPage
+		if cursor != "" {
+			file = nextPage
+		}
+		b, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       b,
+			Header:     make(http.Header),
+		}
+	})
+}
+
+func TestUserIterator(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination *notionapi.Pagination
+		limit      int
+		want       []notionapi.UserID
+	}{
+		{
+			name: "collects users from every page",
+			want: []notionapi.UserID{"first_id", "some_id", "some_id"},
+		},
+		{
+			name:  "stops at the limit",
+			limit: 2,
+			want:  []notionapi.UserID{"first_id", "some_id"},
+		},
+		{
+			name:       "resumes from a saved cursor",
+			pagination: &notionapi.Pagination{StartCursor: "some_cursor"},
+			want:       []notionapi.UserID{"some_id", "some_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newPaginatedClient(t, "testdata/user_list_has_more.json", "testdata/user_list.json")
+			client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+
+			users, err := client.User.ListAll(context.Background(), tt.pagination).Collect(tt.limit)
+			if err != nil {
+				t.Fatalf("Collect() error = %v", err)
+			}
+			got := make([]notionapi.UserID, len(users))
+			for i, u := range users {
+				got[i] = u.ID
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Collect() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageIterator(t *testing.T) {
+	c := newPaginatedClient(t, "testdata/database_query_has_more.json", "testdata/database_query.json")
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+
+	it := client.Database.QueryAll(context.Background(), "some_id", &notionapi.DatabaseQueryRequest{PageSize: 1})
+	var got []notionapi.ObjectID
+	var cursors []notionapi.Cursor
+	for it.Next() {
+		got = append(got, it.Value().ID)
+		cursors = append(cursors, it.Cursor())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	if want := []notionapi.ObjectID{"first_id", "some_id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Value() got = %v, want %v", got, want)
+	}
+	if want := []notionapi.Cursor{"", "some_cursor"}; !reflect.DeepEqual(cursors, want) {
+		t.Errorf("Cursor() got = %v, want %v", cursors, want)
+	}
+}
+
+func TestBlockIterator(t *testing.T) {
+	c := newPaginatedClient(t, "testdata/block_get_children_has_more.json", "testdata/block_get_children.json")
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+
+	blocks, err := client.Block.GetChildrenAll(context.Background(), "some_id", nil).Collect(0)
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(blocks) != 3 {
+		t.Errorf("Collect() got %d blocks, want 3", len(blocks))
+	}
+}
+
+func TestIterator_Err(t *testing.T) {
+	c := newMockedClient(t, "testdata/validation_error.json", http.StatusBadRequest)
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+
+	it := client.Search.DoAll(context.Background(), &notionapi.SearchRequest{Query: "Hel"})
+	if it.Next() {
+		t.Errorf("Next() got = true, want false")
+	}
+	if _, ok := it.Err().(*notionapi.Error); !ok {
+		t.Errorf("Err() got = %v, want *notionapi.Error", it.Err())
+	}
+}
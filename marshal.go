@@ -0,0 +1,538 @@
+package notionapi
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// UnmarshalProperties stores the values of page properties in the struct
+// pointed to by v. Fields are mapped with the "notion" struct tag:
+//
+//	type Task struct {
+//		Name     string           `notion:"Name,title"`
+//		Status   string           `notion:"Status,select"`
+//		Tags     []string         `notion:"Tags,multi_select"`
+//		Estimate *float64         `notion:"Estimate,number"`
+//		Done     bool             `notion:"Done,checkbox"`
+//		Due      time.Time        `notion:"Due,date,dateonly"`
+//		Owners   []UserID         `notion:"Owners,people"`
+//		Parents  []PageID         `notion:"Parents,relation"`
+//		Secret   string           `notion:"-"`
+//	}
+//
+// The property type after the comma is optional for unmarshalling. If set, it
+// has to match the type of the property. Fields without a tag are ignored, as
+// are properties missing from props. Empty properties set the field to its
+// zero value, or nil for pointers. Numbers with a fraction or out of range of
+// an integer field are errors.
+func UnmarshalProperties(props Properties, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("notionapi: UnmarshalProperties needs a non-nil struct pointer, got %T", v)
+	}
+	rv = rv.Elem()
+
+	for i := 0; i < rv.NumField(); i++ {
+		tag, ok := parsePropertyTag(rv.Type().Field(i))
+		if !ok {
+			continue
+		}
+		p, found := props[tag.name]
+		if !found || p == nil {
+			continue
+		}
+		p = indirectProperty(p)
+		if tag.propertyType != "" && p.GetType() != "" && p.GetType() != tag.propertyType {
+			return fmt.Errorf("notionapi: property %q has type %s, not %s", tag.name, p.GetType(), tag.propertyType)
+		}
+
+		value, err := propertyValue(p)
+		if err != nil {
+			return fmt.Errorf("notionapi: property %q: %w", tag.name, err)
+		}
+		if err := assignValue(rv.Field(i), value); err != nil {
+			return fmt.Errorf("notionapi: property %q into field %s: %w", tag.name, rv.Type().Field(i).Name, err)
+		}
+	}
+	return nil
+}
+
+// MarshalProperties converts the tagged fields of the struct v into page
+// properties, see UnmarshalProperties for the struct tags. Without an explicit
+// property type, strings become rich_text, numbers number, bools checkbox,
+// time.Time date, []string multi_select, []UserID people and []PageID relation.
+// The "omitempty" option skips zero values. time.Time fields are sent with
+// their time of day unless they have the "dateonly" option. Read-only properties such as
+// formula or created_time are never marshalled.
+func MarshalProperties(v interface{}) (Properties, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("notionapi: MarshalProperties needs a struct, got %T", v)
+	}
+
+	result := Properties{}
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		tag, ok := parsePropertyTag(field)
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if tag.omitempty && isZeroValue(fv) {
+			continue
+		}
+
+		propertyType := tag.propertyType
+		if propertyType == "" {
+			propertyType = inferPropertyType(fv.Type())
+		}
+		if readOnlyPropertyTypes[propertyType] {
+			continue
+		}
+
+		p, err := buildProperty(propertyType, fv, tag.dateOnly)
+		if err != nil {
+			return nil, fmt.Errorf("notionapi: field %s into property %q: %w", field.Name, tag.name, err)
+		}
+		if p != nil {
+			result[tag.name] = p
+		}
+	}
+	return result, nil
+}
+
+type propertyTag struct {
+	name         string
+	propertyType PropertyType
+	omitempty    bool
+	dateOnly     bool
+}
+
+func parsePropertyTag(field reflect.StructField) (propertyTag, bool) {
+	tag, ok := field.Tag.Lookup("notion")
+	if !ok || tag == "-" || field.PkgPath != "" {
+		return propertyTag{}, false
+	}
+	parts := strings.Split(tag, ",")
+	result := propertyTag{name: parts[0]}
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			result.omitempty = true
+		} else if option == "dateonly" {
+			result.dateOnly = true
+		} else if option != "" {
+			result.propertyType = PropertyType(option)
+		}
+	}
+	if result.name == "" {
+		result.name = field.Name
+	}
+	return result, true
+}
+
+var readOnlyPropertyTypes = map[PropertyType]bool{
+	PropertyTypeFormula:        true,
+	PropertyTypeRollup:         true,
+	PropertyTypeCreatedTime:    true,
+	PropertyTypeCreatedBy:      true,
+	PropertyTypeLastEditedTime: true,
+	PropertyTypeLastEditedBy:   true,
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	paragraphType = reflect.TypeOf(Paragraph{})
+	optionType    = reflect.TypeOf(Option{})
+	userIDType    = reflect.TypeOf(UserID(""))
+	pageIDType    = reflect.TypeOf(PageID(""))
+)
+
+func indirectProperty(p Property) Property {
+	if rv := reflect.ValueOf(p); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		return rv.Elem().Interface().(Property)
+	}
+	return p
+}
+
+// propertyValue returns the value of a page property as one of string,
+// float64, bool, time.Time, Paragraph, Option, []Option, []UserID, []PageID
+// or nil if the property is empty.
+func propertyValue(p Property) (interface{}, error) {
+	switch p := p.(type) {
+	case TitleProperty:
+		return p.Title, nil
+	case RichTextProperty:
+		return p.RichText, nil
+	case SelectProperty:
+		if p.Select == nil || p.Select.Name == "" {
+			return nil, nil
+		}
+		return *p.Select, nil
+	case MultiSelectProperty:
+		return p.MultiSelect, nil
+	case NumberProperty:
+		if p.Number == nil {
+			return nil, nil
+		}
+		return *p.Number, nil
+	case CheckboxProperty:
+		return p.Checkbox, nil
+	case URLProperty:
+		return stringOrNil(p.URL), nil
+	case EmailProperty:
+		return stringOrNil(p.Email), nil
+	case PhoneNumberProperty:
+		return stringOrNil(p.PhoneNumber), nil
+	case DateProperty:
+		return dateStart(p.Date), nil
+	case CreatedTimeProperty:
+		return timeOrNil(p.CreatedTime), nil
+	case LastEditedTimeProperty:
+		return timeOrNil(p.LastEditedTime), nil
+	case PeopleProperty:
+		return p.UserIDs(), nil
+	case CreatedByProperty:
+		return p.CreatedBy.ID, nil
+	case LastEditedByProperty:
+		return p.LastEditedBy.ID, nil
+	case FormulaProperty:
+		f := p.Formula
+		switch f.Type {
+		case FormulaTypeString:
+			if s, ok := f.AsString(); ok {
+				return s, nil
+			}
+		case FormulaTypeNumber:
+			if n, ok := f.AsNumber(); ok {
+				return n, nil
+			}
+		case FormulaTypeBoolean:
+			if b, ok := f.AsBool(); ok {
+				return b, nil
+			}
+		case FormulaTypeDate:
+			return dateStart(f.Date), nil
+		default:
+			return nil, fmt.Errorf("unsupported formula result %q", f.Type)
+		}
+		return nil, nil
+	case RelationProperty:
+		return p.PageIDs(), nil
+	}
+	return nil, fmt.Errorf("unsupported property %T", p)
+}
+
+func stringOrNil(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func timeOrNil(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func dateStart(d *DateObject) interface{} {
+	if d == nil || d.Start == nil {
+		return nil
+	}
+	return time.Time(*d.Start)
+}
+
+func assignValue(field reflect.Value, value interface{}) error {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		if err := assignValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(field.Type()) {
+		field.Set(rv)
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		if field.Kind() == reflect.String {
+			field.SetString(v)
+			return nil
+		}
+	case Paragraph:
+		if field.Kind() == reflect.String {
+			field.SetString(v.PlainText())
+			return nil
+		}
+	case Option:
+		if field.Kind() == reflect.String {
+			field.SetString(v.Name)
+			return nil
+		}
+	case float64:
+		switch field.Kind() {
+		case reflect.Float32, reflect.Float64:
+			field.SetFloat(v)
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 || field.OverflowInt(int64(v)) {
+				return fmt.Errorf("number %v does not fit into %s", v, field.Type())
+			}
+			field.SetInt(int64(v))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v != math.Trunc(v) || v < 0 || v >= math.MaxUint64 || field.OverflowUint(uint64(v)) {
+				return fmt.Errorf("number %v does not fit into %s", v, field.Type())
+			}
+			field.SetUint(uint64(v))
+			return nil
+		}
+	case []Option:
+		names := make([]string, len(v))
+		for i, o := range v {
+			names[i] = o.Name
+		}
+		return assignStrings(field, names)
+	case []UserID:
+		ids := make([]string, len(v))
+		for i, id := range v {
+			ids[i] = id.String()
+		}
+		return assignStrings(field, ids)
+	case []PageID:
+		ids := make([]string, len(v))
+		for i, id := range v {
+			ids[i] = id.String()
+		}
+		return assignStrings(field, ids)
+	}
+	return fmt.Errorf("can not assign %T to %s", value, field.Type())
+}
+
+func assignStrings(field reflect.Value, values []string) error {
+	if field.Kind() != reflect.Slice || field.Type().Elem().Kind() != reflect.String {
+		return fmt.Errorf("can not assign a list of strings to %s", field.Type())
+	}
+	slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+	for i, v := range values {
+		slice.Index(i).SetString(v)
+	}
+	field.Set(slice)
+	return nil
+}
+
+func isZeroValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+func inferPropertyType(t reflect.Type) PropertyType {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return PropertyTypeDate
+	case t == paragraphType:
+		return PropertyTypeRichText
+	case t == optionType:
+		return PropertyTypeSelect
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return PropertyTypeRichText
+	case reflect.Bool:
+		return PropertyTypeCheckbox
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return PropertyTypeNumber
+	case reflect.Slice:
+		switch t.Elem() {
+		case userIDType:
+			return PropertyTypePeople
+		case pageIDType:
+			return PropertyTypeRelation
+		case optionType:
+			return PropertyTypeMultiSelect
+		}
+		if t.Elem().Kind() == reflect.String {
+			return PropertyTypeMultiSelect
+		}
+	}
+	return ""
+}
+
+// buildProperty converts a field value into a page property of the given
+// type. It returns nil for values that can not be sent, e.g. an empty select.
+// Times are sent as dates without time of day if dateOnly is set.
+func buildProperty(propertyType PropertyType, v reflect.Value, dateOnly bool) (Property, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return emptyProperty(propertyType)
+		}
+		v = v.Elem()
+	}
+	value := v.Interface()
+
+	switch propertyType {
+	case PropertyTypeTitle, PropertyTypeRichText:
+		var text Paragraph
+		switch value := value.(type) {
+		case Paragraph:
+			text = value
+		default:
+			if v.Kind() != reflect.String {
+				return nil, fmt.Errorf("can not convert %s to %s", v.Type(), propertyType)
+			}
+			if v.String() != "" {
+				text = Paragraph{{Type: ObjectTypeText, Text: Text{Content: v.String()}}}
+			}
+		}
+		if text == nil {
+			text = Paragraph{}
+		}
+		if propertyType == PropertyTypeTitle {
+			return &TitleProperty{Type: propertyType, Title: text}, nil
+		}
+		return &RichTextProperty{Type: propertyType, RichText: text}, nil
+	case PropertyTypeSelect:
+		option, ok := value.(Option)
+		if !ok {
+			if v.Kind() != reflect.String {
+				return nil, fmt.Errorf("can not convert %s to %s", v.Type(), propertyType)
+			}
+			option = Option{Name: v.String()}
+		}
+		if option.Name == "" {
+			return nil, nil
+		}
+		return &SelectProperty{Type: propertyType, Select: &option}, nil
+	case PropertyTypeMultiSelect:
+		if options, ok := value.([]Option); ok {
+			return &MultiSelectProperty{Type: propertyType, MultiSelect: options}, nil
+		}
+		names, err := stringsOf(v, propertyType)
+		if err != nil {
+			return nil, err
+		}
+		options := make([]Option, len(names))
+		for i, name := range names {
+			options[i] = Option{Name: name}
+		}
+		return &MultiSelectProperty{Type: propertyType, MultiSelect: options}, nil
+	case PropertyTypeNumber:
+		var n float64
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = float64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = float64(v.Uint())
+		case reflect.Float32, reflect.Float64:
+			n = v.Float()
+		default:
+			return nil, fmt.Errorf("can not convert %s to %s", v.Type(), propertyType)
+		}
+		return &NumberProperty{Type: propertyType, Number: &n}, nil
+	case PropertyTypeCheckbox:
+		if v.Kind() != reflect.Bool {
+			return nil, fmt.Errorf("can not convert %s to %s", v.Type(), propertyType)
+		}
+		return &CheckboxProperty{Type: propertyType, Checkbox: v.Bool()}, nil
+	case PropertyTypeURL, PropertyTypeEmail, PropertyTypePhoneNumber:
+		if v.Kind() != reflect.String {
+			return nil, fmt.Errorf("can not convert %s to %s", v.Type(), propertyType)
+		}
+		s := v.String()
+		switch propertyType {
+		case PropertyTypeURL:
+			return &URLProperty{Type: propertyType, URL: s}, nil
+		case PropertyTypeEmail:
+			return &EmailProperty{Type: propertyType, Email: s}, nil
+		}
+		return &PhoneNumberProperty{Type: propertyType, PhoneNumber: s}, nil
+	case PropertyTypeDate:
+		t, ok := value.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("can not convert %s to %s", v.Type(), propertyType)
+		}
+		if t.IsZero() {
+			return emptyProperty(propertyType)
+		}
+		if dateOnly {
+			return &DateProperty{Type: propertyType, Date: NewDate(t)}, nil
+		}
+		return &DateProperty{Type: propertyType, Date: NewDateTime(t)}, nil
+	case PropertyTypePeople:
+		ids, err := stringsOf(v, propertyType)
+		if err != nil {
+			return nil, err
+		}
+		people := make([]User, len(ids))
+		for i, id := range ids {
+			people[i] = User{Object: ObjectTypeUser, ID: UserID(id)}
+		}
+		return &PeopleProperty{Type: propertyType, People: people}, nil
+	case PropertyTypeRelation:
+		ids, err := stringsOf(v, propertyType)
+		if err != nil {
+			return nil, err
+		}
+		relation := make([]Relation, len(ids))
+		for i, id := range ids {
+			relation[i] = Relation{ID: PageID(id)}
+		}
+		return &RelationProperty{Type: propertyType, Relation: relation}, nil
+	case "":
+		return nil, fmt.Errorf("can not infer the property type of %s", v.Type())
+	}
+	return nil, fmt.Errorf("unsupported property type %s", propertyType)
+}
+
+// emptyProperty returns a property clearing the value, or nil if the type
+// can not be cleared
+func emptyProperty(propertyType PropertyType) (Property, error) {
+	switch propertyType {
+	case PropertyTypeNumber:
+		return &NumberProperty{Type: propertyType}, nil
+	case PropertyTypeDate:
+		return &DateProperty{Type: propertyType}, nil
+	case PropertyTypeURL:
+		return &URLProperty{Type: propertyType}, nil
+	case PropertyTypeEmail:
+		return &EmailProperty{Type: propertyType}, nil
+	case PropertyTypePhoneNumber:
+		return &PhoneNumberProperty{Type: propertyType}, nil
+	}
+	return nil, nil
+}
+
+func stringsOf(v reflect.Value, propertyType PropertyType) ([]string, error) {
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.String {
+		return nil, fmt.Errorf("can not convert %s to %s", v.Type(), propertyType)
+	}
+	result := make([]string, v.Len())
+	for i := range result {
+		result[i] = v.Index(i).String()
+	}
+	return result, nil
+}
@@ -0,0 +1,203 @@
+package notionapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jomei/notionapi"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type task struct {
+	Name     string             `notion:"Name,title"`
+	Status   string             `notion:"Status,select"`
+	Tags     []string           `notion:"Tags,multi_select"`
+	Estimate float64            `notion:"Estimate,number"`
+	Points   *int               `notion:"Points,number"`
+	Done     bool               `notion:"Done"`
+	Due      time.Time          `notion:"Due,date,dateonly"`
+	Owners   []notionapi.UserID `notion:"Owners"`
+	Parents  []notionapi.PageID `notion:"Parents"`
+	Link     string             `notion:"Link,url,omitempty"`
+	Missing  string             `notion:"Missing,rich_text,omitempty"`
+	Ignored  string             `notion:"-"`
+	Untagged string
+}
+
+func TestUnmarshalProperties(t *testing.T) {
+	c := newMockedClient(t, "testdata/page_get_row.json", http.StatusOK)
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+	page, err := client.Page.Get(context.Background(), "some_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("fills tagged fields", func(t *testing.T) {
+		got := task{Ignored: "keep", Untagged: "keep"}
+		if err := notionapi.UnmarshalProperties(page.Properties, &got); err != nil {
+			t.Fatalf("UnmarshalProperties() error = %v", err)
+		}
+		want := task{
+			Name:     "Write docs",
+			Status:   "In progress",
+			Tags:     []string{"docs", "urgent"},
+			Estimate: 3.5,
+			Done:     true,
+			Due:      time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			Owners:   []notionapi.UserID{"some_user_id"},
+			Parents:  []notionapi.PageID{"some_page_id"},
+			Ignored:  "keep",
+			Untagged: "keep",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("UnmarshalProperties() got = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("fails on type mismatch", func(t *testing.T) {
+		var got struct {
+			Status string `notion:"Status,multi_select"`
+		}
+		if err := notionapi.UnmarshalProperties(page.Properties, &got); err == nil {
+			t.Error("UnmarshalProperties() error = nil, want error")
+		}
+	})
+
+	t.Run("fails on incompatible field", func(t *testing.T) {
+		var got struct {
+			Done string `notion:"Done"`
+		}
+		if err := notionapi.UnmarshalProperties(page.Properties, &got); err == nil {
+			t.Error("UnmarshalProperties() error = nil, want error")
+		}
+	})
+
+	t.Run("fails on numbers not fitting into integers", func(t *testing.T) {
+		number := func(n float64) notionapi.Properties {
+			return notionapi.Properties{"Points": &notionapi.NumberProperty{Type: notionapi.PropertyTypeNumber, Number: &n}}
+		}
+
+		var whole struct {
+			Points int `notion:"Points,number"`
+		}
+		if err := notionapi.UnmarshalProperties(number(3), &whole); err != nil || whole.Points != 3 {
+			t.Errorf("UnmarshalProperties() got %d, error = %v, want 3", whole.Points, err)
+		}
+		var fraction struct {
+			Estimate int `notion:"Estimate,number"`
+		}
+		err := notionapi.UnmarshalProperties(page.Properties, &fraction)
+		if err == nil || !strings.Contains(err.Error(), "number 3.5 does not fit into int") {
+			t.Errorf("UnmarshalProperties() error = %v", err)
+		}
+		var small struct {
+			Points int8 `notion:"Points,number"`
+		}
+		err = notionapi.UnmarshalProperties(number(300), &small)
+		if err == nil || !strings.Contains(err.Error(), "number 300 does not fit into int8") {
+			t.Errorf("UnmarshalProperties() error = %v", err)
+		}
+		var unsigned struct {
+			Points uint `notion:"Points,number"`
+		}
+		err = notionapi.UnmarshalProperties(number(-1), &unsigned)
+		if err == nil || !strings.Contains(err.Error(), "number -1 does not fit into uint") {
+			t.Errorf("UnmarshalProperties() error = %v", err)
+		}
+	})
+
+	t.Run("needs a struct pointer", func(t *testing.T) {
+		if err := notionapi.UnmarshalProperties(page.Properties, task{}); err == nil {
+			t.Error("UnmarshalProperties() error = nil, want error")
+		}
+	})
+}
+
+func TestMarshalProperties(t *testing.T) {
+	points := 5
+	props, err := notionapi.MarshalProperties(task{
+		Name:     "Write docs",
+		Status:   "In progress",
+		Tags:     []string{"docs"},
+		Estimate: 3.5,
+		Points:   &points,
+		Done:     true,
+		Due:      time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+		Owners:   []notionapi.UserID{"some_user_id"},
+		Parents:  []notionapi.PageID{"some_page_id"},
+		Ignored:  "ignored",
+	})
+	if err != nil {
+		t.Fatalf("MarshalProperties() error = %v", err)
+	}
+
+	got, err := json.Marshal(props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{` +
+		`"Done":{"type":"checkbox","checkbox":true},` +
+		`"Due":{"type":"date","date":{"start":"2021-06-01"}},` +
+		`"Estimate":{"type":"number","number":3.5},` +
+		`"Name":{"type":"title","title":[{"type":"text","text":{"content":"Write docs"}}]},` +
+		`"Owners":{"type":"people","people":[{"object":"user","id":"some_user_id"}]},` +
+		`"Parents":{"type":"relation","relation":[{"id":"some_page_id"}]},` +
+		`"Points":{"type":"number","number":5},` +
+		`"Status":{"type":"select","select":{"name":"In progress"}},` +
+		`"Tags":{"type":"multi_select","multi_select":[{"name":"docs"}]}` +
+		`}`
+	if string(got) != want {
+		t.Errorf("MarshalProperties() got = %s, want %s", got, want)
+	}
+
+	t.Run("round trips", func(t *testing.T) {
+		in := task{
+			Name:    "Write docs",
+			Status:  "Done",
+			Tags:    []string{"a", "b"},
+			Due:     time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			Owners:  []notionapi.UserID{"user"},
+			Parents: []notionapi.PageID{"page"},
+		}
+		props, err := notionapi.MarshalProperties(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out task
+		if err := notionapi.UnmarshalProperties(props, &out); err != nil {
+			t.Fatalf("UnmarshalProperties() error = %v", err)
+		}
+		if !reflect.DeepEqual(out, in) {
+			t.Errorf("got = %+v, want %+v", out, in)
+		}
+	})
+
+	t.Run("sends times with time of day", func(t *testing.T) {
+		props, err := notionapi.MarshalProperties(struct {
+			Start time.Time `notion:"Start,date"`
+		}{time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := json.Marshal(props)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"Start":{"type":"date","date":{"start":"2021-06-01T00:00:00Z"}}}`
+		if string(got) != want {
+			t.Errorf("MarshalProperties() got = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("fails on unsupported field", func(t *testing.T) {
+		_, err := notionapi.MarshalProperties(struct {
+			Value map[string]string `notion:"Value"`
+		}{})
+		if err == nil {
+			t.Error("MarshalProperties() error = nil, want error")
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package notionapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Call describes a single API operation passed through the middleware chain.
+type Call struct {
+	// Operation is the logical name of the endpoint, e.g. "pages.get" or
+	// "databases.query".
+	Operation string
+	// ObjectID is the ID of the database, page, block or user the operation
+	// targets. It is empty for operations like search or listing users.
+	ObjectID string
+	Method   string
+	// Path is relative to the versioned API URL, e.g. "pages/<id>".
+	Path  string
+	Query map[string]string
+	// Body is the request body before it is marshalled to JSON.
+	Body interface{}
+	// Header holds additional headers sent with the request.
+	Header http.Header
+
+	decode func(io.Reader) (interface{}, error)
+}
+
+// Doer executes a Call and returns the decoded response. Failed API calls
+// return an *Error.
+type Doer interface {
+	Do(context.Context, *Call) (interface{}, error)
+}
+
+// DoerFunc adapts a function to the Doer interface
+type DoerFunc func(context.Context, *Call) (interface{}, error)
+
+func (f DoerFunc) Do(ctx context.Context, call *Call) (interface{}, error) {
+	return f(ctx, call)
+}
+
+// Middleware wraps a Doer to inspect or modify calls and their results.
+// A middleware may replace the response, but it has to keep its type.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middlewares around every request. The first middleware
+// is the outermost one and sees a call first.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+func chainMiddlewares(middlewares []Middleware, doer Doer) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+	return doer
+}
+
+// decodeJSON returns a decoder that unmarshals the response body into v
+func decodeJSON(v interface{}) func(io.Reader) (interface{}, error) {
+	return func(r io.Reader) (interface{}, error) {
+		if err := json.NewDecoder(r).Decode(v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
+
+func unexpectedResponse(call *Call, v interface{}) error {
+	return fmt.Errorf("%s: unexpected response type %T", call.Operation, v)
+}
@@ -1,6 +1,11 @@
 package notionapi
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
 
 type ObjectType string
 
@@ -24,17 +29,126 @@ func (c Color) String() string {
 	return string(c)
 }
 
+// RichText is a span of text, a mention or an equation. Type tells which of
+// Text, Mention and Equation is used, an empty Type means text. Rich text of
+// a type this library does not support yet keeps its original JSON in Raw
+// and marshals back to it unchanged.
 type RichText struct {
+	Type        ObjectType      `json:"type,omitempty"`
+	Text        Text            `json:"text"`
+	Mention     *Mention        `json:"mention,omitempty"`
+	Equation    *Equation       `json:"equation,omitempty"`
+	Annotations *Annotations    `json:"annotations,omitempty"`
+	PlainText   string          `json:"plain_text,omitempty"`
+	Href        string          `json:"href,omitempty"`
+	Raw         json.RawMessage `json:"-"`
+}
+
+// supported reports whether the type of r is one of text, mention and
+// equation
+func (r RichText) supported() bool {
+	switch r.Type {
+	case "", ObjectTypeText, ObjectTypeMention, ObjectTypeEquation:
+		return true
+	}
+	return false
+}
+
+// richTextJSON is the wire format of RichText with only the variant of its
+// type set
+type richTextJSON struct {
 	Type        ObjectType   `json:"type,omitempty"`
-	Text        Text         `json:"text"`
+	Text        *Text        `json:"text,omitempty"`
+	Mention     *Mention     `json:"mention,omitempty"`
+	Equation    *Equation    `json:"equation,omitempty"`
 	Annotations *Annotations `json:"annotations,omitempty"`
 	PlainText   string       `json:"plain_text,omitempty"`
 	Href        string       `json:"href,omitempty"`
 }
 
+func (r RichText) MarshalJSON() ([]byte, error) {
+	if !r.supported() {
+		if r.Raw == nil {
+			return nil, fmt.Errorf("unsupported rich text type: %s", r.Type)
+		}
+		return r.Raw, nil
+	}
+	out := richTextJSON{Type: r.Type, Annotations: r.Annotations, PlainText: r.PlainText, Href: r.Href}
+	switch r.Type {
+	case ObjectTypeMention:
+		out.Mention = r.Mention
+	case ObjectTypeEquation:
+		out.Equation = r.Equation
+	default:
+		text := r.Text
+		out.Text = &text
+	}
+	return json.Marshal(out)
+}
+
+func (r *RichText) UnmarshalJSON(data []byte) error {
+	var in richTextJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*r = RichText{Type: in.Type, Annotations: in.Annotations, PlainText: in.PlainText, Href: in.Href}
+	switch in.Type {
+	case ObjectTypeMention:
+		r.Mention = in.Mention
+	case ObjectTypeEquation:
+		r.Equation = in.Equation
+	default:
+		if !r.supported() {
+			r.Raw = append(json.RawMessage(nil), data...)
+		} else if in.Text != nil {
+			r.Text = *in.Text
+		}
+	}
+	return nil
+}
+
 type Text struct {
 	Content string `json:"content"`
-	Link    string `json:"link,omitempty"`
+	Link    *Link  `json:"link,omitempty"`
+}
+
+// Link is the target of linked text
+type Link struct {
+	URL string `json:"url"`
+}
+
+type MentionType string
+
+func (mt MentionType) String() string {
+	return string(mt)
+}
+
+// Mention refers to a user, page, database, date or link inside rich text.
+// Type tells which field is set.
+type Mention struct {
+	Type        MentionType      `json:"type"`
+	User        *User            `json:"user,omitempty"`
+	Page        *PageMention     `json:"page,omitempty"`
+	Database    *DatabaseMention `json:"database,omitempty"`
+	Date        *DateObject      `json:"date,omitempty"`
+	LinkPreview *LinkPreview     `json:"link_preview,omitempty"`
+}
+
+type PageMention struct {
+	ID PageID `json:"id"`
+}
+
+type DatabaseMention struct {
+	ID DatabaseID `json:"id"`
+}
+
+type LinkPreview struct {
+	URL string `json:"url"`
+}
+
+// Equation is an inline KaTeX expression
+type Equation struct {
+	Expression string `json:"expression"`
 }
 
 type Annotations struct {
@@ -48,6 +162,45 @@ type Annotations struct {
 
 type Paragraph []RichText
 
+// PlainText returns the text without annotations. Rich text created locally
+// has no PlainText, the content of its text is used instead.
+func (p Paragraph) PlainText() string {
+	var b strings.Builder
+	for _, rt := range p {
+		if rt.PlainText != "" {
+			b.WriteString(rt.PlainText)
+		} else {
+			b.WriteString(rt.Text.Content)
+		}
+	}
+	return b.String()
+}
+
+type FileType string
+
+func (ft FileType) String() string {
+	return string(ft)
+}
+
+// FileObject points to a file. URLs of files hosted by Notion expire at ExpiryTime.
+type FileObject struct {
+	URL        string     `json:"url"`
+	ExpiryTime *time.Time `json:"expiry_time,omitempty"`
+}
+
+type IconType string
+
+func (it IconType) String() string {
+	return string(it)
+}
+
+type Icon struct {
+	Type     IconType    `json:"type"`
+	Emoji    string      `json:"emoji,omitempty"`
+	File     *FileObject `json:"file,omitempty"`
+	External *FileObject `json:"external,omitempty"`
+}
+
 type FormulaObject struct {
 	Value string `json:"value"`
 }
@@ -78,3 +231,170 @@ func (d *Date) String() string {
 func (d *Date) MarshalText() ([]byte, error) {
 	return []byte(d.String()), nil
 }
+
+// UnmarshalText parses dates with or without a time of day
+func (d *Date) UnmarshalText(data []byte) error {
+	t, err := time.Parse(time.RFC3339Nano, string(data))
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", string(data)); err != nil {
+			return err
+		}
+	}
+	*d = Date(t)
+	return nil
+}
+
+// DateObject is the value of a date property, optionally a range with an
+// End. DateOnly dates have no time of day and marshal as 2006-01-02. Dates
+// with a TimeZone marshal without UTC offset in that time zone, as Notion
+// expects.
+type DateObject struct {
+	Start    *Date  `json:"start"`
+	End      *Date  `json:"end,omitempty"`
+	TimeZone string `json:"time_zone,omitempty"`
+	DateOnly bool   `json:"-"`
+}
+
+// NewDate returns a date-only date on the day of t
+func NewDate(t time.Time) *DateObject {
+	start := Date(t)
+	return &DateObject{Start: &start, DateOnly: true}
+}
+
+// NewDateTime returns a date starting at the time t
+func NewDateTime(t time.Time) *DateObject {
+	start := Date(t)
+	return &DateObject{Start: &start}
+}
+
+// StartTime returns the start in loc, the zero time if there is no start.
+// Date-only dates are midnight of that day in loc. A nil loc means UTC.
+func (d DateObject) StartTime(loc *time.Location) time.Time {
+	return d.timeIn(d.Start, loc)
+}
+
+// EndTime returns the end in loc, the zero time if the date is no range. See
+// StartTime.
+func (d DateObject) EndTime(loc *time.Location) time.Time {
+	return d.timeIn(d.End, loc)
+}
+
+func (d DateObject) timeIn(date *Date, loc *time.Location) time.Time {
+	if date == nil {
+		return time.Time{}
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	t := time.Time(*date)
+	if d.DateOnly {
+		year, month, day := t.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+	return t.In(loc)
+}
+
+const (
+	dateLayout      = "2006-01-02"
+	localTimeLayout = "2006-01-02T15:04:05.999999999"
+)
+
+func (d DateObject) MarshalJSON() ([]byte, error) {
+	start, err := d.format(d.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := d.format(d.End)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Start    *string `json:"start"`
+		End      *string `json:"end,omitempty"`
+		TimeZone string  `json:"time_zone,omitempty"`
+	}{start, end, d.TimeZone})
+}
+
+func (d DateObject) format(date *Date) (*string, error) {
+	if date == nil {
+		return nil, nil
+	}
+	s, err := d.text(time.Time(*date))
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// text formats t as date-only, as local time of the time zone or as RFC 3339
+func (d DateObject) text(t time.Time) (string, error) {
+	switch {
+	case d.DateOnly:
+		return t.Format(dateLayout), nil
+	case d.TimeZone != "":
+		loc, err := location(d.TimeZone)
+		if err != nil {
+			return "", err
+		}
+		return t.In(loc).Format(localTimeLayout), nil
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
+func (d *DateObject) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Start    *string `json:"start"`
+		End      *string `json:"end"`
+		TimeZone *string `json:"time_zone"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*d = DateObject{}
+	if raw.TimeZone != nil {
+		d.TimeZone = *raw.TimeZone
+	}
+	if raw.Start != nil {
+		d.DateOnly = len(*raw.Start) == len(dateLayout)
+	}
+	var err error
+	if d.Start, err = d.parse(raw.Start); err != nil {
+		return err
+	}
+	d.End, err = d.parse(raw.End)
+	return err
+}
+
+// parse reads a date-only, local or RFC 3339 time. Local times are in the
+// time zone of the date.
+func (d DateObject) parse(s *string) (*Date, error) {
+	if s == nil {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, *s)
+	if err != nil {
+		loc, err := location(d.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		if t, err = time.ParseInLocation(localTimeLayout, *s, loc); err != nil {
+			if t, err = time.ParseInLocation(dateLayout, *s, time.UTC); err != nil {
+				return nil, fmt.Errorf("invalid date %q", *s)
+			}
+		}
+	}
+	date := Date(t)
+	return &date, nil
+}
+
+// location loads a time zone, UTC if name is empty
+func location(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
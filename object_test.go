@@ -0,0 +1,236 @@
+package notionapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jomei/notionapi"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDateObject(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		json      string
+		wantStart time.Time
+		wantEnd   time.Time
+		dateOnly  bool
+	}{
+		{
+			name:      "date only",
+			json:      `{"start":"2021-06-01"}`,
+			wantStart: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			dateOnly:  true,
+		},
+		{
+			name:      "date only range",
+			json:      `{"start":"2021-06-01","end":"2021-06-03"}`,
+			wantStart: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2021, 6, 3, 0, 0, 0, 0, time.UTC),
+			dateOnly:  true,
+		},
+		{
+			name:      "datetime",
+			json:      `{"start":"2021-06-01T10:30:00+02:00"}`,
+			wantStart: time.Date(2021, 6, 1, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name:      "datetime range",
+			json:      `{"start":"2021-06-01T10:30:00Z","end":"2021-06-01T12:00:00.5Z"}`,
+			wantStart: time.Date(2021, 6, 1, 10, 30, 0, 0, time.UTC),
+			wantEnd:   time.Date(2021, 6, 1, 12, 0, 0, 500000000, time.UTC),
+		},
+		{
+			name:      "time zone",
+			json:      `{"start":"2021-06-01T10:30:00","end":"2021-06-01T11:00:00","time_zone":"America/New_York"}`,
+			wantStart: time.Date(2021, 6, 1, 10, 30, 0, 0, newYork),
+			wantEnd:   time.Date(2021, 6, 1, 11, 0, 0, 0, newYork),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d notionapi.DateObject
+			if err := json.Unmarshal([]byte(tt.json), &d); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if d.DateOnly != tt.dateOnly {
+				t.Errorf("DateOnly got = %v, want %v", d.DateOnly, tt.dateOnly)
+			}
+			if got := d.StartTime(nil); !got.Equal(tt.wantStart) {
+				t.Errorf("StartTime() got = %v, want %v", got, tt.wantStart)
+			}
+			if got := d.EndTime(nil); !got.Equal(tt.wantEnd) {
+				t.Errorf("EndTime() got = %v, want %v", got, tt.wantEnd)
+			}
+
+			got, err := json.Marshal(d)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.json {
+				t.Errorf("Marshal() got = %s, want %s", got, tt.json)
+			}
+		})
+	}
+
+	t.Run("null", func(t *testing.T) {
+		var p notionapi.DateProperty
+		if err := json.Unmarshal([]byte(`{"type":"date","date":null}`), &p); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if p.Date != nil {
+			t.Errorf("Date got = %+v, want nil", p.Date)
+		}
+	})
+
+	t.Run("unknown time zone", func(t *testing.T) {
+		var d notionapi.DateObject
+		err := json.Unmarshal([]byte(`{"start":"2021-06-01T10:30:00","time_zone":"Europe/Berln"}`), &d)
+		if err == nil || err.Error() != `unknown time zone "Europe/Berln"` {
+			t.Errorf("Unmarshal() error = %v", err)
+		}
+
+		d = *notionapi.NewDateTime(time.Date(2021, 6, 1, 14, 30, 0, 0, time.UTC))
+		d.TimeZone = "Europe/Berln"
+		if _, err := json.Marshal(d); err == nil || !strings.Contains(err.Error(), `unknown time zone "Europe/Berln"`) {
+			t.Errorf("Marshal() error = %v", err)
+		}
+		f := &notionapi.PropertyFilter{Property: "Due", Date: &notionapi.DateFilterCondition{After: &d}}
+		if _, err := json.Marshal(f); err == nil || !strings.Contains(err.Error(), `unknown time zone "Europe/Berln"`) {
+			t.Errorf("Marshal() of a filter error = %v", err)
+		}
+	})
+
+	t.Run("converts to location", func(t *testing.T) {
+		day := notionapi.NewDate(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+		if got, want := day.StartTime(newYork), time.Date(2021, 6, 1, 0, 0, 0, 0, newYork); !got.Equal(want) {
+			t.Errorf("StartTime() of a date-only got = %v, want %v", got, want)
+		}
+
+		datetime := notionapi.NewDateTime(time.Date(2021, 6, 1, 14, 30, 0, 0, time.UTC))
+		got := datetime.StartTime(newYork)
+		if got.Location() != newYork || got.Hour() != 10 || !got.Equal(time.Date(2021, 6, 1, 14, 30, 0, 0, time.UTC)) {
+			t.Errorf("StartTime() of a datetime got = %v", got)
+		}
+		if !datetime.EndTime(newYork).IsZero() {
+			t.Errorf("EndTime() without end got = %v, want zero time", datetime.EndTime(newYork))
+		}
+	})
+}
+
+func TestRichText(t *testing.T) {
+	data := `[
+		{"type":"text","text":{"content":"Hi ","link":null},"plain_text":"Hi ","href":null},
+		{"type":"mention","mention":{"type":"user","user":{"object":"user","id":"some_user_id"}},"plain_text":"@Ann"},
+		{"type":"mention","mention":{"type":"page","page":{"id":"some_page_id"}},"plain_text":"Roadmap"},
+		{"type":"mention","mention":{"type":"database","database":{"id":"some_database_id"}},"plain_text":"Tasks"},
+		{"type":"mention","mention":{"type":"date","date":{"start":"2021-06-01","end":null}},"plain_text":"2021-06-01"},
+		{"type":"mention","mention":{"type":"link_preview","link_preview":{"url":"https://example.com/pr/1"}},"plain_text":"https://example.com/pr/1"},
+		{"type":"equation","equation":{"expression":"E=mc^2"},"plain_text":"E=mc^2"},
+		{"type":"text","text":{"content":"docs","link":{"url":"https://example.com/docs"}},"plain_text":"docs","href":"https://example.com/docs"}
+	]`
+	var p notionapi.Paragraph
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(p) != 8 {
+		t.Fatalf("Unmarshal() got %d items, want 8", len(p))
+	}
+
+	if p[0].Text.Content != "Hi " || p[0].Text.Link != nil || p[0].Mention != nil || p[0].Equation != nil {
+		t.Errorf("text got = %+v", p[0])
+	}
+	if p[7].Text.Link == nil || p[7].Text.Link.URL != "https://example.com/docs" {
+		t.Errorf("link got = %+v", p[7].Text.Link)
+	}
+	mentions := []struct {
+		got  *notionapi.Mention
+		want notionapi.MentionType
+		ok   bool
+	}{
+		{p[1].Mention, notionapi.MentionTypeUser, p[1].Mention != nil && p[1].Mention.User != nil && p[1].Mention.User.ID == "some_user_id"},
+		{p[2].Mention, notionapi.MentionTypePage, p[2].Mention != nil && p[2].Mention.Page != nil && p[2].Mention.Page.ID == "some_page_id"},
+		{p[3].Mention, notionapi.MentionTypeDatabase, p[3].Mention != nil && p[3].Mention.Database != nil && p[3].Mention.Database.ID == "some_database_id"},
+		{p[4].Mention, notionapi.MentionTypeDate, p[4].Mention != nil && p[4].Mention.Date != nil && p[4].Mention.Date.DateOnly},
+		{p[5].Mention, notionapi.MentionTypeLinkPreview, p[5].Mention != nil && p[5].Mention.LinkPreview != nil && p[5].Mention.LinkPreview.URL == "https://example.com/pr/1"},
+	}
+	for i, m := range mentions {
+		if !m.ok || m.got.Type != m.want {
+			t.Errorf("mention %d got = %+v, want %s", i, m.got, m.want)
+		}
+		if p[i+1].Text != (notionapi.Text{}) {
+			t.Errorf("mention %d has text %+v", i, p[i+1].Text)
+		}
+	}
+	if p[6].Equation == nil || p[6].Equation.Expression != "E=mc^2" {
+		t.Errorf("equation got = %+v", p[6])
+	}
+
+	t.Run("marshals only the variant of its type", func(t *testing.T) {
+		got, err := json.Marshal(p[1:3])
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		want := `[{"type":"mention","mention":{"type":"user","user":{"object":"user","id":"some_user_id"}},"plain_text":"@Ann"},` +
+			`{"type":"mention","mention":{"type":"page","page":{"id":"some_page_id"}},"plain_text":"Roadmap"}]`
+		if string(got) != want {
+			t.Errorf("Marshal() got = %s, want %s", got, want)
+		}
+	})
+}
+
+func TestParagraph_PlainText(t *testing.T) {
+	p := notionapi.Paragraph{
+		{Type: notionapi.ObjectTypeText, Text: notionapi.Text{Content: "Hi "}, PlainText: "Hi "},
+		{Type: notionapi.ObjectTypeText, Text: notionapi.Text{Content: "there"}},
+		{Type: notionapi.ObjectTypeMention, PlainText: " @Ann"},
+	}
+	if got, want := p.PlainText(), "Hi there @Ann"; got != want {
+		t.Errorf("PlainText() got = %q, want %q", got, want)
+	}
+}
+
+func TestRichText_Unknown(t *testing.T) {
+	data := `{"type":"template","template":{"name":"weekly"},"plain_text":"Weekly"}`
+	var r notionapi.RichText
+	if err := json.Unmarshal([]byte(data), &r); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if r.Type != "template" || r.PlainText != "Weekly" || string(r.Raw) != data {
+		t.Errorf("Unmarshal() got = %+v", r)
+	}
+
+	got, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(got) != data {
+		t.Errorf("Marshal() got = %s, want %s", got, data)
+	}
+
+	_, err = json.Marshal(notionapi.RichText{Type: "template", PlainText: "Weekly"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported rich text type: template") {
+		t.Errorf("Marshal() without Raw error = %v", err)
+	}
+
+	t.Run("fails in strict mode", func(t *testing.T) {
+		body := `{"object":"list","results":[{"object":"block","id":"some_id","type":"paragraph",` +
+			`"paragraph":{"text":[` + data + `]}}],"next_cursor":null,"has_more":false}`
+		c := newTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body)), Header: make(http.Header)}
+		})
+		client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c), notionapi.WithStrictDecoding())
+		_, err := client.Block.GetChildren(context.Background(), "some_id", nil)
+		if err == nil || err.Error() != "unsupported rich text type: template" {
+			t.Errorf("GetChildren() error = %v", err)
+		}
+	})
+}
@@ -2,10 +2,11 @@ package notionapi
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 type PageID string
@@ -26,22 +27,41 @@ type PageClient struct {
 
 // Get https://developers.notion.com/reference/get-page
 func (pc *PageClient) Get(ctx context.Context, id PageID) (*Page, error) {
-	res, err := pc.apiClient.request(ctx, http.MethodGet, fmt.Sprintf("pages/%s", id.String()), nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return handlePageResponse(res)
+	return pc.call(ctx, &Call{
+		Operation: "pages.get",
+		ObjectID:  id.String(),
+		Method:    http.MethodGet,
+		Path:      fmt.Sprintf("pages/%s", id.String()),
+	})
 }
 
 // Create https://developers.notion.com/reference/post-page
+//
+// Children which don't fit into one request are appended with
+// BlockService.AppendAll after the page is created. If that fails, the
+// page is returned along with the error and retrying Create would create
+// another page.
 func (pc *PageClient) Create(ctx context.Context, requestBody *PageCreateRequest) (*Page, error) {
-	res, err := pc.apiClient.request(ctx, http.MethodPost, "pages", nil, requestBody)
-	if err != nil {
-		return nil, err
+	var children []Block
+	if requestBody != nil && !fitsAppend(requestBody.Children) {
+		children = requestBody.Children
+		r := *requestBody
+		r.Children = nil
+		requestBody = &r
 	}
-
-	return handlePageResponse(res)
+	page, err := pc.call(ctx, &Call{
+		Operation: "pages.create",
+		Method:    http.MethodPost,
+		Path:      "pages",
+		Body:      requestBody,
+	})
+	if err != nil || children == nil {
+		return page, err
+	}
+	if err := pc.apiClient.Block.AppendAll(ctx, BlockID(page.ID), children, nil); err != nil {
+		return page, errors.Wrapf(err, "append children of page %s", page.ID)
+	}
+	return page, nil
 }
 
 type PageUpdateRequest struct {
@@ -50,12 +70,27 @@ type PageUpdateRequest struct {
 
 // Update https://developers.notion.com/reference/patch-page
 func (pc *PageClient) Update(ctx context.Context, id PageID, request *PageUpdateRequest) (*Page, error) {
-	res, err := pc.apiClient.request(ctx, http.MethodPatch, fmt.Sprintf("pages/%s", id.String()), nil, request)
+	return pc.call(ctx, &Call{
+		Operation: "pages.update",
+		ObjectID:  id.String(),
+		Method:    http.MethodPatch,
+		Path:      fmt.Sprintf("pages/%s", id.String()),
+		Body:      request,
+	})
+}
+
+func (pc *PageClient) call(ctx context.Context, call *Call) (*Page, error) {
+	call.decode = decodeJSON(&Page{})
+	res, err := pc.apiClient.call(ctx, call)
 	if err != nil {
 		return nil, err
 	}
 
-	return handlePageResponse(res)
+	response, ok := res.(*Page)
+	if !ok {
+		return nil, unexpectedResponse(call, res)
+	}
+	return response, nil
 }
 
 type Page struct {
@@ -84,15 +119,7 @@ type Parent struct {
 type PageCreateRequest struct {
 	Parent     Parent     `json:"parent"`
 	Properties Properties `json:"properties"`
-	Children   []Block    `json:"children,omitempty"`
-}
-
-func handlePageResponse(res *http.Response) (*Page, error) {
-	var response Page
-	err := json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
+	// Children are sent unchanged if they fit into one request. Otherwise
+	// Create appends them with BlockService.AppendAll.
+	Children []Block `json:"children,omitempty"`
 }
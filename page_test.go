@@ -2,9 +2,13 @@ package notionapi_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/jomei/notionapi"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -105,7 +109,7 @@ func TestPageClient(t *testing.T) {
 						DatabaseID: "f830be5eff534859932e5b81542b3c7b",
 					},
 					Properties: notionapi.Properties{
-						"Name": notionapi.PageTitleProperty{
+						"Name": notionapi.TitleProperty{
 							Title: notionapi.Paragraph{
 								{Text: notionapi.Text{Content: "hello"}},
 							},
@@ -145,6 +149,81 @@ func TestPageClient(t *testing.T) {
 		}
 	})
 
+	t.Run("Create appends large content", func(t *testing.T) {
+		children := make([]notionapi.Block, 150)
+		for i := range children {
+			b := &notionapi.ParagraphBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeParagraph}
+			b.Paragraph.Text = notionapi.Paragraph{{Type: notionapi.ObjectTypeText, Text: notionapi.Text{Content: fmt.Sprint("p", i)}}}
+			children[i] = b
+		}
+		request := &notionapi.PageCreateRequest{
+			Parent:   notionapi.Parent{PageID: "parent_id"},
+			Children: children,
+		}
+
+		store := &blockStore{t: t, children: make(map[string][]map[string]interface{})}
+		c := newTestClient(func(req *http.Request) *http.Response {
+			if req.URL.Path != "/v1/pages" {
+				return store.roundTrip(req)
+			}
+			var body map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := body["children"]; ok {
+				t.Errorf("Create() sent children with the page")
+			}
+			return newMockedClientResponse(t, "testdata/page_create.json", http.StatusOK)
+		})
+		client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c), notionapi.WithValidation())
+		got, err := client.Page.Create(context.Background(), request)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		if got.ID != "some_id" {
+			t.Errorf("Create() got page %s, want some_id", got.ID)
+		}
+		if n := len(store.children["some_id"]); n != 150 {
+			t.Errorf("Create() appended %d blocks, want 150", n)
+		}
+		if len(request.Children) != 150 {
+			t.Errorf("Create() modified the request")
+		}
+	})
+
+	t.Run("Create returns the page if appending fails", func(t *testing.T) {
+		children := make([]notionapi.Block, 150)
+		for i := range children {
+			b := &notionapi.ParagraphBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeParagraph}
+			b.Paragraph.Text = notionapi.Paragraph{{Type: notionapi.ObjectTypeText, Text: notionapi.Text{Content: fmt.Sprint("p", i)}}}
+			children[i] = b
+		}
+
+		c := newTestClient(func(req *http.Request) *http.Response {
+			if req.URL.Path == "/v1/pages" {
+				return newMockedClientResponse(t, "testdata/page_create.json", http.StatusOK)
+			}
+			return newMockedClientResponse(t, "testdata/validation_error.json", http.StatusBadRequest)
+		})
+		client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+		got, err := client.Page.Create(context.Background(), &notionapi.PageCreateRequest{
+			Parent:   notionapi.Parent{PageID: "parent_id"},
+			Children: children,
+		})
+
+		if got == nil || got.ID != "some_id" {
+			t.Fatalf("Create() got page %v, want some_id", got)
+		}
+		var apiErr *notionapi.Error
+		if !errors.As(err, &apiErr) || apiErr.Code != notionapi.ErrorCodeValidationError {
+			t.Errorf("Create() error = %v, want the validation error of the append", err)
+		}
+		if err == nil || !strings.Contains(err.Error(), "append children of page some_id") {
+			t.Errorf("Create() error = %v, want the page ID", err)
+		}
+	})
+
 	t.Run("Update", func(t *testing.T) {
 		tests := []struct {
 			name       string
@@ -206,3 +285,239 @@ func TestPageClient(t *testing.T) {
 		}
 	})
 }
+
+func TestUnknownProperty(t *testing.T) {
+	filePath := "testdata/page_get_unknown_property.json"
+
+	t.Run("keeps raw json", func(t *testing.T) {
+		c := newMockedClient(t, filePath, http.StatusOK)
+		client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+		got, err := client.Page.Get(context.Background(), "some_id")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+
+		p, ok := got.Properties["Status"].(*notionapi.UnknownProperty)
+		if !ok {
+			t.Fatalf("Get() got %T, want *notionapi.UnknownProperty", got.Properties["Status"])
+		}
+		if p.ID != "kT~F" || p.GetType() != "status" {
+			t.Errorf("Get() got = %+v", p)
+		}
+
+		j, err := json.Marshal(got.Properties["Status"])
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		want := `{"id":"kT~F","type":"status","status":{"id":"some_id","name":"In progress","color":"blue"}}`
+		if string(j) != want {
+			t.Errorf("Marshal() got = %s, want %s", j, want)
+		}
+	})
+
+	t.Run("fails in strict mode", func(t *testing.T) {
+		c := newMockedClient(t, filePath, http.StatusOK)
+		client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c), notionapi.WithStrictDecoding())
+		_, err := client.Page.Get(context.Background(), "some_id")
+		if err == nil || err.Error() != "unsupported property type: status" {
+			t.Errorf("Get() error = %v", err)
+		}
+	})
+}
+
+func TestPageProperties(t *testing.T) {
+	c := newMockedClient(t, "testdata/page_get_row.json", http.StatusOK)
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+	got, err := client.Page.Get(context.Background(), "some_id")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	estimate := 3.5
+	due := notionapi.Date(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name string
+		want notionapi.Property
+	}{
+		{
+			name: "Status",
+			want: &notionapi.SelectProperty{
+				ID:     "^OE@",
+				Type:   notionapi.PropertyTypeSelect,
+				Select: &notionapi.Option{ID: "some_id", Name: "In progress", Color: notionapi.ColorBlue},
+			},
+		},
+		{
+			name: "Estimate",
+			want: &notionapi.NumberProperty{ID: "Fx=K", Type: notionapi.PropertyTypeNumber, Number: &estimate},
+		},
+		{
+			name: "Points",
+			want: &notionapi.NumberProperty{ID: "Kq]o", Type: notionapi.PropertyTypeNumber},
+		},
+		{
+			name: "Done",
+			want: &notionapi.CheckboxProperty{ID: "Xn;u", Type: notionapi.PropertyTypeCheckbox, Checkbox: true},
+		},
+		{
+			name: "Due",
+			want: &notionapi.DateProperty{ID: "M;Bw", Type: notionapi.PropertyTypeDate, Date: &notionapi.DateObject{Start: &due, DateOnly: true}},
+		},
+		{
+			name: "Owners",
+			want: &notionapi.PeopleProperty{
+				ID:   "rJt\\",
+				Type: notionapi.PropertyTypePeople,
+				People: []notionapi.User{{
+					Object: notionapi.ObjectTypeUser,
+					ID:     "some_user_id",
+					Type:   notionapi.UserTypePerson,
+					Name:   "some name",
+					Person: &notionapi.Person{Email: "some@email.com"},
+				}},
+			},
+		},
+		{
+			name: "Parents",
+			want: &notionapi.RelationProperty{ID: "a~Lt", Type: notionapi.PropertyTypeRelation, Relation: []notionapi.Relation{{ID: "some_page_id"}}},
+		},
+		{
+			name: "Link",
+			want: &notionapi.URLProperty{ID: "o^Kv", Type: notionapi.PropertyTypeURL},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(got.Properties[tt.name], tt.want) {
+				t.Errorf("Get() got = %+v, want %+v", got.Properties[tt.name], tt.want)
+			}
+		})
+	}
+
+	t.Run("marshals empty values to null", func(t *testing.T) {
+		j, err := json.Marshal(got.Properties["Link"])
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		want := `{"id":"o^Kv","type":"url","url":null}`
+		if string(j) != want {
+			t.Errorf("Marshal() got = %s, want %s", j, want)
+		}
+	})
+}
+
+func TestPagePropertyValues(t *testing.T) {
+	c := newMockedClient(t, "testdata/page_get_values.json", http.StatusOK)
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c))
+	page, err := client.Page.Get(context.Background(), "some_id")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	formula := func(name string) notionapi.FormulaValue {
+		p, ok := page.Properties[name].(*notionapi.FormulaProperty)
+		if !ok {
+			t.Fatalf("%s got %T, want *notionapi.FormulaProperty", name, page.Properties[name])
+		}
+		return p.Formula
+	}
+	rollup := func(name string) notionapi.RollupValue {
+		p, ok := page.Properties[name].(*notionapi.RollupProperty)
+		if !ok {
+			t.Fatalf("%s got %T, want *notionapi.RollupProperty", name, page.Properties[name])
+		}
+		return p.Rollup
+	}
+
+	t.Run("formula", func(t *testing.T) {
+		if s, ok := formula("Label").AsString(); !ok || s != "Write docs (3.5)" {
+			t.Errorf("AsString() got = %q, %v", s, ok)
+		}
+		if n, ok := formula("Total").AsNumber(); !ok || n != 7 {
+			t.Errorf("AsNumber() got = %v, %v", n, ok)
+		}
+		if b, ok := formula("Late").AsBool(); !ok || b {
+			t.Errorf("AsBool() got = %v, %v", b, ok)
+		}
+		d, ok := formula("Reminder").AsDate()
+		if !ok || d.Start == nil || !time.Time(*d.Start).Equal(time.Date(2021, 5, 31, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("AsDate() got = %+v, %v", d, ok)
+		}
+		if _, ok := formula("Empty formula").AsNumber(); ok {
+			t.Error("AsNumber() of an empty result ok = true, want false")
+		}
+		if _, ok := formula("Total").AsString(); ok {
+			t.Error("AsString() of a number ok = true, want false")
+		}
+	})
+
+	t.Run("rollup", func(t *testing.T) {
+		if n, ok := rollup("Sum").AsNumber(); !ok || n != 12 {
+			t.Errorf("AsNumber() got = %v, %v", n, ok)
+		}
+		d, ok := rollup("Latest").AsDate()
+		if !ok || d.Start == nil || !time.Time(*d.Start).Equal(time.Date(2021, 6, 1, 10, 30, 0, 0, time.UTC)) {
+			t.Errorf("AsDate() got = %+v, %v", d, ok)
+		}
+		n := 2.0
+		want := []notionapi.Property{
+			&notionapi.TitleProperty{Type: notionapi.PropertyTypeTitle, Title: notionapi.Paragraph{{
+				Type:      notionapi.ObjectTypeText,
+				Text:      notionapi.Text{Content: "Release"},
+				PlainText: "Release",
+			}}},
+			&notionapi.NumberProperty{Type: notionapi.PropertyTypeNumber, Number: &n},
+		}
+		if got := rollup("Parent names").AsArray(); !reflect.DeepEqual(got, want) {
+			t.Errorf("AsArray() got = %+v, want %+v", got, want)
+		}
+		if got := rollup("Sum").AsArray(); got != nil {
+			t.Errorf("AsArray() of a number got = %+v, want nil", got)
+		}
+	})
+
+	t.Run("relation", func(t *testing.T) {
+		got := page.Properties["Parents"].(*notionapi.RelationProperty).PageIDs()
+		want := []notionapi.PageID{"some_page_id", "another_page_id"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("PageIDs() got = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("people", func(t *testing.T) {
+		p := page.Properties["Owners"].(*notionapi.PeopleProperty)
+		want := []notionapi.UserID{"some_user_id", "some_bot_id"}
+		if got := p.UserIDs(); !reflect.DeepEqual(got, want) {
+			t.Errorf("UserIDs() got = %v, want %v", got, want)
+		}
+		if p.People[0].Person == nil || p.People[0].Person.Email != "some@email.com" {
+			t.Errorf("People[0] got = %+v", p.People[0])
+		}
+		if p.People[1].Type != notionapi.UserTypeBot || p.People[1].Bot == nil {
+			t.Errorf("People[1] got = %+v", p.People[1])
+		}
+	})
+
+	t.Run("files", func(t *testing.T) {
+		p := page.Properties["Attachments"].(*notionapi.FilesProperty)
+		want := []string{"https://files.example.com/spec.pdf", "https://example.com/logo.png"}
+		if got := p.URLs(); !reflect.DeepEqual(got, want) {
+			t.Errorf("URLs() got = %v, want %v", got, want)
+		}
+		expiry := time.Date(2021, 5, 24, 6, 6, 34, 827000000, time.UTC)
+		if p.Files[0].Expired(expiry.Add(-time.Second)) || !p.Files[0].Expired(expiry) {
+			t.Errorf("Expired() of a hosted file got wrong result around %v", expiry)
+		}
+		if p.Files[1].Expired(expiry.AddDate(1, 0, 0)) {
+			t.Error("Expired() of an external file = true, want false")
+		}
+	})
+
+	t.Run("created and edited by", func(t *testing.T) {
+		if got := page.Properties["Created by"].(*notionapi.CreatedByProperty).CreatedBy.ID; got != "some_user_id" {
+			t.Errorf("CreatedBy got = %v", got)
+		}
+		if got := page.Properties["Edited by"].(*notionapi.LastEditedByProperty).LastEditedBy.ID; got != "some_bot_id" {
+			t.Errorf("LastEditedBy got = %v", got)
+		}
+	})
+}
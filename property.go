@@ -2,143 +2,451 @@ package notionapi
 
 import (
 	"encoding/json"
-	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
 type PropertyType string
 
-type Property interface {
-	GetType() PropertyType
-}
-
 type PropertyID string
 
 func (pID PropertyID) String() string {
 	return string(pID)
 }
 
-type TextProperty struct {
+type FormatType string
+
+func (ft FormatType) String() string {
+	return string(ft)
+}
+
+// PropertyConfig is the configuration of a database property, such as the
+// options of a select or the format of a number. See Property for the values
+// of page properties.
+type PropertyConfig interface {
+	GetType() PropertyType
+}
+
+type TitlePropertyConfig struct {
 	ID    PropertyID   `json:"id,omitempty"`
 	Type  PropertyType `json:"type"`
-	Title []RichText   `json:"title"`
+	Title struct{}     `json:"title"`
 }
 
-func (p TextProperty) GetType() PropertyType {
+func (p TitlePropertyConfig) GetType() PropertyType {
 	return p.Type
 }
 
-type EmptyRichTextProperty struct {
+type RichTextPropertyConfig struct {
 	ID       PropertyID   `json:"id,omitempty"`
 	Type     PropertyType `json:"type"`
 	RichText struct{}     `json:"rich_text"`
 }
 
-func (p EmptyRichTextProperty) GetType() PropertyType {
+func (p RichTextPropertyConfig) GetType() PropertyType {
 	return p.Type
 }
 
-type RichTextProperty struct {
+type NumberPropertyConfig struct {
+	ID     PropertyID   `json:"id,omitempty"`
+	Type   PropertyType `json:"type"`
+	Number NumberFormat `json:"number"`
+}
+
+type NumberFormat struct {
+	Format FormatType `json:"format"`
+}
+
+func (p NumberPropertyConfig) GetType() PropertyType {
+	return p.Type
+}
+
+type SelectPropertyConfig struct {
+	ID     PropertyID   `json:"id,omitempty"`
+	Type   PropertyType `json:"type"`
+	Select Select       `json:"select"`
+}
+
+func (p SelectPropertyConfig) GetType() PropertyType {
+	return p.Type
+}
+
+type MultiSelectPropertyConfig struct {
+	ID          PropertyID   `json:"id,omitempty"`
+	Type        PropertyType `json:"type"`
+	MultiSelect Select       `json:"multi_select"`
+}
+
+func (p MultiSelectPropertyConfig) GetType() PropertyType {
+	return p.Type
+}
+
+type Select struct {
+	Options []Option `json:"options"`
+}
+
+type Option struct {
+	ID    PropertyID `json:"id,omitempty"`
+	Name  string     `json:"name"`
+	Color Color      `json:"color,omitempty"`
+}
+
+type DatePropertyConfig struct {
+	ID   PropertyID   `json:"id,omitempty"`
+	Type PropertyType `json:"type"`
+	Date struct{}     `json:"date"`
+}
+
+func (p DatePropertyConfig) GetType() PropertyType {
+	return p.Type
+}
+
+type PeoplePropertyConfig struct {
+	ID     PropertyID   `json:"id,omitempty"`
+	Type   PropertyType `json:"type"`
+	People struct{}     `json:"people"`
+}
+
+func (p PeoplePropertyConfig) GetType() PropertyType {
+	return p.Type
+}
+
+type FilesPropertyConfig struct {
+	ID    PropertyID   `json:"id,omitempty"`
+	Type  PropertyType `json:"type"`
+	Files struct{}     `json:"files"`
+}
+
+func (p FilesPropertyConfig) GetType() PropertyType {
+	return p.Type
+}
+
+type CheckboxPropertyConfig struct {
 	ID       PropertyID   `json:"id,omitempty"`
 	Type     PropertyType `json:"type"`
-	RichText []RichText   `json:"rich_text"`
+	Checkbox struct{}     `json:"checkbox"`
 }
 
-func (p RichTextProperty) GetType() PropertyType {
+func (p CheckboxPropertyConfig) GetType() PropertyType {
 	return p.Type
 }
 
-type DatabaseTitleProperty struct {
+type URLPropertyConfig struct {
+	ID   PropertyID   `json:"id,omitempty"`
+	Type PropertyType `json:"type"`
+	URL  struct{}     `json:"url"`
+}
+
+func (p URLPropertyConfig) GetType() PropertyType {
+	return p.Type
+}
+
+type EmailPropertyConfig struct {
 	ID    PropertyID   `json:"id,omitempty"`
 	Type  PropertyType `json:"type"`
-	Title RichText     `json:"title"`
+	Email struct{}     `json:"email"`
 }
 
-func (p DatabaseTitleProperty) GetType() PropertyType {
+func (p EmailPropertyConfig) GetType() PropertyType {
 	return p.Type
 }
 
-type PageTitleProperty struct {
-	ID    PropertyID   `json:"id,omitempty"`
-	Type  PropertyType `json:"type,omitempty"`
-	Title Paragraph    `json:"title"`
+type PhoneNumberPropertyConfig struct {
+	ID          PropertyID   `json:"id,omitempty"`
+	Type        PropertyType `json:"type"`
+	PhoneNumber struct{}     `json:"phone_number"`
 }
 
-func (p PageTitleProperty) GetType() PropertyType {
+func (p PhoneNumberPropertyConfig) GetType() PropertyType {
 	return p.Type
 }
 
-type FormatType string
+type FormulaPropertyConfig struct {
+	ID      PropertyID    `json:"id,omitempty"`
+	Type    PropertyType  `json:"type"`
+	Formula FormulaConfig `json:"formula"`
+}
 
-func (ft FormatType) String() string {
-	return string(ft)
+type FormulaConfig struct {
+	Expression string `json:"expression"`
 }
 
-type NumberProperty struct {
-	ID     ObjectID     `json:"id,omitempty"`
-	Type   PropertyType `json:"type"`
-	Format FormatType   `json:"format"`
+func (p FormulaPropertyConfig) GetType() PropertyType {
+	return p.Type
 }
 
-func (p NumberProperty) GetType() PropertyType {
+type RelationPropertyConfig struct {
+	ID       PropertyID     `json:"id,omitempty"`
+	Type     PropertyType   `json:"type"`
+	Relation RelationConfig `json:"relation"`
+}
+
+type RelationConfig struct {
+	DatabaseID         DatabaseID `json:"database_id"`
+	SyncedPropertyID   PropertyID `json:"synced_property_id,omitempty"`
+	SyncedPropertyName string     `json:"synced_property_name,omitempty"`
+}
+
+func (p RelationPropertyConfig) GetType() PropertyType {
 	return p.Type
 }
 
-type SelectProperty struct {
-	ID     ObjectID     `json:"id,omitempty"`
+type RollupPropertyConfig struct {
+	ID     PropertyID   `json:"id,omitempty"`
 	Type   PropertyType `json:"type"`
-	Select Select       `json:"select"`
+	Rollup RollupConfig `json:"rollup"`
 }
 
-func (p SelectProperty) GetType() PropertyType {
+type RollupConfig struct {
+	RelationPropertyName string       `json:"relation_property_name,omitempty"`
+	RelationPropertyID   PropertyID   `json:"relation_property_id,omitempty"`
+	RollupPropertyName   string       `json:"rollup_property_name,omitempty"`
+	RollupPropertyID     PropertyID   `json:"rollup_property_id,omitempty"`
+	Function             FunctionType `json:"function"`
+}
+
+func (p RollupPropertyConfig) GetType() PropertyType {
 	return p.Type
 }
 
-type SelectOptionProperty struct {
-	ID     ObjectID     `json:"id,omitempty"`
-	Type   PropertyType `json:"type"`
-	Select Option       `json:"select"`
+type CreatedTimePropertyConfig struct {
+	ID          PropertyID   `json:"id,omitempty"`
+	Type        PropertyType `json:"type"`
+	CreatedTime struct{}     `json:"created_time"`
 }
 
-func (p SelectOptionProperty) GetType() PropertyType {
+func (p CreatedTimePropertyConfig) GetType() PropertyType {
 	return p.Type
 }
 
-type Select struct {
-	Options []Option `json:"options"`
+type CreatedByPropertyConfig struct {
+	ID        PropertyID   `json:"id,omitempty"`
+	Type      PropertyType `json:"type"`
+	CreatedBy struct{}     `json:"created_by"`
 }
 
-type MultiSelectProperty struct {
-	ID          ObjectID     `json:"id,omitempty"`
-	Type        PropertyType `json:"type"`
-	MultiSelect Select       `json:"multi_select"`
+func (p CreatedByPropertyConfig) GetType() PropertyType {
+	return p.Type
 }
 
-func (p MultiSelectProperty) GetType() PropertyType {
+type LastEditedTimePropertyConfig struct {
+	ID             PropertyID   `json:"id,omitempty"`
+	Type           PropertyType `json:"type"`
+	LastEditedTime struct{}     `json:"last_edited_time"`
+}
+
+func (p LastEditedTimePropertyConfig) GetType() PropertyType {
 	return p.Type
 }
 
-type MultiSelectOptionsProperty struct {
-	ID          ObjectID     `json:"id,omitempty"`
-	Type        PropertyType `json:"type"`
-	MultiSelect []Option     `json:"multi_select"`
+type LastEditedByPropertyConfig struct {
+	ID           PropertyID   `json:"id,omitempty"`
+	Type         PropertyType `json:"type"`
+	LastEditedBy struct{}     `json:"last_edited_by"`
 }
 
-func (p MultiSelectOptionsProperty) GetType() PropertyType {
+func (p LastEditedByPropertyConfig) GetType() PropertyType {
 	return p.Type
 }
 
-type Option struct {
-	ID    PropertyID `json:"id,omitempty"`
-	Name  string     `json:"name"`
-	Color Color      `json:"color,omitempty"`
+// UnknownPropertyConfig holds the configuration of a property type this
+// library does not support yet. It keeps the original JSON and marshals back
+// to it unchanged.
+type UnknownPropertyConfig struct {
+	ID   PropertyID      `json:"id,omitempty"`
+	Type PropertyType    `json:"type"`
+	Raw  json.RawMessage `json:"-"`
 }
 
+func (p UnknownPropertyConfig) GetType() PropertyType {
+	return p.Type
+}
+
+func (p *UnknownPropertyConfig) UnmarshalJSON(data []byte) error {
+	type config UnknownPropertyConfig
+	var tmp config
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*p = UnknownPropertyConfig(tmp)
+	p.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+func (p UnknownPropertyConfig) MarshalJSON() ([]byte, error) {
+	if p.Raw != nil {
+		return p.Raw, nil
+	}
+	type config UnknownPropertyConfig
+	return json.Marshal(config(p))
+}
+
+// PropertyConfigs are the properties of a database keyed by name
+type PropertyConfigs map[string]PropertyConfig
+
+func (p *PropertyConfigs) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	configs, err := parsePropertyConfigs(raw)
+	if err != nil {
+		return err
+	}
+	*p = configs
+	return nil
+}
+
+func parsePropertyConfigs(raw map[string]json.RawMessage) (PropertyConfigs, error) {
+	result := make(PropertyConfigs)
+	for k, data := range raw {
+		var p PropertyConfig
+		switch propertyTypeOf(data) {
+		case PropertyTypeTitle:
+			p = &TitlePropertyConfig{}
+		case PropertyTypeRichText:
+			p = &RichTextPropertyConfig{}
+		case PropertyTypeNumber:
+			p = &NumberPropertyConfig{}
+		case PropertyTypeSelect:
+			p = &SelectPropertyConfig{}
+		case PropertyTypeMultiSelect:
+			p = &MultiSelectPropertyConfig{}
+		case PropertyTypeDate:
+			p = &DatePropertyConfig{}
+		case PropertyTypePeople:
+			p = &PeoplePropertyConfig{}
+		case PropertyTypeFiles:
+			p = &FilesPropertyConfig{}
+		case PropertyTypeCheckbox:
+			p = &CheckboxPropertyConfig{}
+		case PropertyTypeURL:
+			p = &URLPropertyConfig{}
+		case PropertyTypeEmail:
+			p = &EmailPropertyConfig{}
+		case PropertyTypePhoneNumber:
+			p = &PhoneNumberPropertyConfig{}
+		case PropertyTypeFormula:
+			p = &FormulaPropertyConfig{}
+		case PropertyTypeRelation:
+			p = &RelationPropertyConfig{}
+		case PropertyTypeRollup:
+			p = &RollupPropertyConfig{}
+		case PropertyTypeCreatedTime:
+			p = &CreatedTimePropertyConfig{}
+		case PropertyTypeCreatedBy:
+			p = &CreatedByPropertyConfig{}
+		case PropertyTypeLastEditedTime:
+			p = &LastEditedTimePropertyConfig{}
+		case PropertyTypeLastEditedBy:
+			p = &LastEditedByPropertyConfig{}
+		default:
+			p = &UnknownPropertyConfig{}
+		}
+
+		if err := json.Unmarshal(data, p); err != nil {
+			return nil, errors.Wrapf(err, "unsupported property format of %s", k)
+		}
+		result[k] = p
+	}
+	return result, nil
+}
+
+// PropertyUpdate renames a database property or changes its type and
+// configuration. A nil *PropertyUpdate removes the property.
+type PropertyUpdate struct {
+	// Name is the new name of the property, empty to keep the current one
+	Name string
+	// Property is the new type and configuration, nil to keep the current one
+	Property PropertyConfig
+}
+
+func (u PropertyUpdate) MarshalJSON() ([]byte, error) {
+	fields := map[string]json.RawMessage{}
+	if u.Property != nil {
+		j, err := json.Marshal(u.Property)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(j, &fields); err != nil {
+			return nil, err
+		}
+		delete(fields, "id")
+	}
+	if u.Name != "" {
+		name, err := json.Marshal(u.Name)
+		if err != nil {
+			return nil, err
+		}
+		fields["name"] = name
+	}
+	return json.Marshal(fields)
+}
+
+// Property is the value of a page property. See PropertyConfig for the
+// configuration of database properties.
+type Property interface {
+	GetType() PropertyType
+}
+
+type TitleProperty struct {
+	ID    PropertyID   `json:"id,omitempty"`
+	Type  PropertyType `json:"type,omitempty"`
+	Title Paragraph    `json:"title"`
+}
+
+func (p TitleProperty) GetType() PropertyType {
+	return p.Type
+}
+
+type RichTextProperty struct {
+	ID       PropertyID   `json:"id,omitempty"`
+	Type     PropertyType `json:"type,omitempty"`
+	RichText Paragraph    `json:"rich_text"`
+}
+
+func (p RichTextProperty) GetType() PropertyType {
+	return p.Type
+}
+
+// NumberProperty is the value of a number property, nil if it is empty
+type NumberProperty struct {
+	ID     PropertyID   `json:"id,omitempty"`
+	Type   PropertyType `json:"type,omitempty"`
+	Number *float64     `json:"number"`
+}
+
+func (p NumberProperty) GetType() PropertyType {
+	return p.Type
+}
+
+// SelectProperty is the selected option, nil if none is selected
+type SelectProperty struct {
+	ID     PropertyID   `json:"id,omitempty"`
+	Type   PropertyType `json:"type,omitempty"`
+	Select *Option      `json:"select"`
+}
+
+func (p SelectProperty) GetType() PropertyType {
+	return p.Type
+}
+
+type MultiSelectProperty struct {
+	ID          PropertyID   `json:"id,omitempty"`
+	Type        PropertyType `json:"type,omitempty"`
+	MultiSelect []Option     `json:"multi_select"`
+}
+
+func (p MultiSelectProperty) GetType() PropertyType {
+	return p.Type
+}
+
+// DateProperty is the value of a date property, nil if it is empty
 type DateProperty struct {
-	ID   ObjectID     `json:"id,omitempty"`
-	Type PropertyType `json:"type"`
-	Date interface{}  `json:"date"`
+	ID   PropertyID   `json:"id,omitempty"`
+	Type PropertyType `json:"type,omitempty"`
+	Date *DateObject  `json:"date"`
 }
 
 func (p DateProperty) GetType() PropertyType {
@@ -146,112 +454,310 @@ func (p DateProperty) GetType() PropertyType {
 }
 
 type PeopleProperty struct {
-	ID     ObjectID     `json:"id,omitempty"`
-	Type   PropertyType `json:"type"`
-	People interface{}  `json:"people"`
+	ID     PropertyID   `json:"id,omitempty"`
+	Type   PropertyType `json:"type,omitempty"`
+	People []User       `json:"people"`
 }
 
 func (p PeopleProperty) GetType() PropertyType {
 	return p.Type
 }
 
-type FileProperty struct {
-	ID   ObjectID     `json:"id,omitempty"`
-	Type PropertyType `json:"type"`
-	File interface{}  `json:"file"`
+// UserIDs returns the IDs of the people
+func (p PeopleProperty) UserIDs() []UserID {
+	ids := make([]UserID, len(p.People))
+	for i, u := range p.People {
+		ids[i] = u.ID
+	}
+	return ids
 }
 
-func (p FileProperty) GetType() PropertyType {
+type FilesProperty struct {
+	ID    PropertyID   `json:"id,omitempty"`
+	Type  PropertyType `json:"type,omitempty"`
+	Files []File       `json:"files"`
+}
+
+func (p FilesProperty) GetType() PropertyType {
 	return p.Type
 }
 
+// URLs returns the URLs of the files
+func (p FilesProperty) URLs() []string {
+	urls := make([]string, len(p.Files))
+	for i, f := range p.Files {
+		urls[i] = f.URL()
+	}
+	return urls
+}
+
+// File is a file of a files property, either hosted by Notion or external
+type File struct {
+	Name     string      `json:"name"`
+	Type     FileType    `json:"type,omitempty"`
+	File     *FileObject `json:"file,omitempty"`
+	External *FileObject `json:"external,omitempty"`
+}
+
+// URL returns the URL of a hosted or external file
+func (f File) URL() string {
+	switch {
+	case f.File != nil:
+		return f.File.URL
+	case f.External != nil:
+		return f.External.URL
+	}
+	return ""
+}
+
+// Expired reports whether the URL of a file hosted by Notion has expired at
+// t. External files never expire.
+func (f File) Expired(t time.Time) bool {
+	return f.File != nil && f.File.ExpiryTime != nil && !t.Before(*f.File.ExpiryTime)
+}
+
 type CheckboxProperty struct {
-	ID       ObjectID     `json:"id,omitempty"`
-	Type     PropertyType `json:"type"`
-	Checkbox interface{}  `json:"checkbox"`
+	ID       PropertyID   `json:"id,omitempty"`
+	Type     PropertyType `json:"type,omitempty"`
+	Checkbox bool         `json:"checkbox"`
 }
 
 func (p CheckboxProperty) GetType() PropertyType {
 	return p.Type
 }
 
+// URLProperty is the value of a url property. An empty URL marshals to null,
+// which clears the property.
 type URLProperty struct {
-	ID   ObjectID     `json:"id,omitempty"`
-	Type PropertyType `json:"type"`
-	URL  interface{}  `json:"url"`
+	ID   PropertyID   `json:"id,omitempty"`
+	Type PropertyType `json:"type,omitempty"`
+	URL  string       `json:"url"`
 }
 
 func (p URLProperty) GetType() PropertyType {
 	return p.Type
 }
 
+func (p URLProperty) MarshalJSON() ([]byte, error) {
+	type property URLProperty
+	return json.Marshal(struct {
+		property
+		URL *string `json:"url"`
+	}{property(p), nullString(p.URL)})
+}
+
+// EmailProperty is the value of an email property. An empty Email marshals
+// to null, which clears the property.
 type EmailProperty struct {
 	ID    PropertyID   `json:"id,omitempty"`
-	Type  PropertyType `json:"type"`
-	Email interface{}  `json:"email"`
+	Type  PropertyType `json:"type,omitempty"`
+	Email string       `json:"email"`
 }
 
 func (p EmailProperty) GetType() PropertyType {
 	return p.Type
 }
 
+func (p EmailProperty) MarshalJSON() ([]byte, error) {
+	type property EmailProperty
+	return json.Marshal(struct {
+		property
+		Email *string `json:"email"`
+	}{property(p), nullString(p.Email)})
+}
+
+// PhoneNumberProperty is the value of a phone_number property. An empty
+// PhoneNumber marshals to null, which clears the property.
 type PhoneNumberProperty struct {
-	ID          ObjectID     `json:"id,omitempty"`
-	Type        PropertyType `json:"type"`
-	PhoneNumber interface{}  `json:"phone_number"`
+	ID          PropertyID   `json:"id,omitempty"`
+	Type        PropertyType `json:"type,omitempty"`
+	PhoneNumber string       `json:"phone_number"`
 }
 
 func (p PhoneNumberProperty) GetType() PropertyType {
 	return p.Type
 }
 
+func (p PhoneNumberProperty) MarshalJSON() ([]byte, error) {
+	type property PhoneNumberProperty
+	return json.Marshal(struct {
+		property
+		PhoneNumber *string `json:"phone_number"`
+	}{property(p), nullString(p.PhoneNumber)})
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// FormulaProperty is the result of a formula property
 type FormulaProperty struct {
-	ID         ObjectID     `json:"id,omitempty"`
-	Type       PropertyType `json:"type"`
-	Expression string       `json:"expression"`
+	ID      PropertyID   `json:"id,omitempty"`
+	Type    PropertyType `json:"type,omitempty"`
+	Formula FormulaValue `json:"formula"`
 }
 
 func (p FormulaProperty) GetType() PropertyType {
 	return p.Type
 }
 
-type RelationProperty struct {
-	Type     PropertyType `json:"type"`
-	Relation Relation     `json:"relation"`
+type FormulaType string
+
+func (ft FormulaType) String() string {
+	return string(ft)
 }
 
-type Relation struct {
-	DatabaseID         DatabaseID `json:"database_id"`
-	SyncedPropertyID   PropertyID `json:"synced_property_id"`
-	SyncedPropertyName string     `json:"synced_property_name"`
+// FormulaValue holds the result of a formula. Type tells which field is set,
+// the field is nil if the result is empty.
+type FormulaValue struct {
+	Type    FormulaType `json:"type"`
+	String  *string     `json:"string,omitempty"`
+	Number  *float64    `json:"number,omitempty"`
+	Boolean *bool       `json:"boolean,omitempty"`
+	Date    *DateObject `json:"date,omitempty"`
+}
+
+// AsString returns the result of a formula of type string. It reports false
+// for other types and empty results.
+func (f FormulaValue) AsString() (string, bool) {
+	if f.Type != FormulaTypeString || f.String == nil {
+		return "", false
+	}
+	return *f.String, true
+}
+
+// AsNumber returns the result of a formula of type number. It reports false
+// for other types and empty results.
+func (f FormulaValue) AsNumber() (float64, bool) {
+	if f.Type != FormulaTypeNumber || f.Number == nil {
+		return 0, false
+	}
+	return *f.Number, true
+}
+
+// AsBool returns the result of a formula of type boolean. It reports false
+// for other types and empty results.
+func (f FormulaValue) AsBool() (bool, bool) {
+	if f.Type != FormulaTypeBoolean || f.Boolean == nil {
+		return false, false
+	}
+	return *f.Boolean, true
+}
+
+// AsDate returns the result of a formula of type date. It reports false for
+// other types and empty results.
+func (f FormulaValue) AsDate() (DateObject, bool) {
+	if f.Type != FormulaTypeDate || f.Date == nil {
+		return DateObject{}, false
+	}
+	return *f.Date, true
+}
+
+type RelationProperty struct {
+	ID       PropertyID   `json:"id,omitempty"`
+	Type     PropertyType `json:"type,omitempty"`
+	Relation []Relation   `json:"relation"`
 }
 
 func (p RelationProperty) GetType() PropertyType {
 	return p.Type
 }
 
-type RollupProperty struct {
-	ID     ObjectID     `json:"id,omitempty"`
-	Type   PropertyType `json:"type"`
-	Rollup Rollup       `json:"rollup"`
+// PageIDs returns the IDs of the related pages
+func (p RelationProperty) PageIDs() []PageID {
+	ids := make([]PageID, len(p.Relation))
+	for i, r := range p.Relation {
+		ids[i] = r.ID
+	}
+	return ids
 }
 
-type Rollup struct {
-	RelationPropertyName string       `json:"relation_property_name"`
-	RelationPropertyID   PropertyID   `json:"relation_property_id"`
-	RollupPropertyName   string       `json:"rollup_property_name"`
-	RollupPropertyID     PropertyID   `json:"rollup_property_id"`
-	Function             FunctionType `json:"function"`
+// Relation points to a related page
+type Relation struct {
+	ID PageID `json:"id"`
+}
+
+// RollupProperty is the result of a rollup property
+type RollupProperty struct {
+	ID     PropertyID   `json:"id,omitempty"`
+	Type   PropertyType `json:"type,omitempty"`
+	Rollup RollupValue  `json:"rollup"`
 }
 
 func (p RollupProperty) GetType() PropertyType {
 	return p.Type
 }
 
+type RollupType string
+
+func (rt RollupType) String() string {
+	return string(rt)
+}
+
+// RollupValue holds the result of a rollup. Type tells which field is set.
+// The items of an array have no ID, as they are the values of the rolled up
+// property of each related page.
+type RollupValue struct {
+	Type   RollupType  `json:"type"`
+	Number *float64    `json:"number,omitempty"`
+	Date   *DateObject `json:"date,omitempty"`
+	Array  []Property  `json:"array,omitempty"`
+}
+
+func (r *RollupValue) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type   RollupType        `json:"type"`
+		Number *float64          `json:"number"`
+		Date   *DateObject       `json:"date"`
+		Array  []json.RawMessage `json:"array"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = RollupValue{Type: raw.Type, Number: raw.Number, Date: raw.Date}
+	for i, item := range raw.Array {
+		p, err := parseProperty(item)
+		if err != nil {
+			return errors.Wrapf(err, "rollup array item %d", i)
+		}
+		r.Array = append(r.Array, p)
+	}
+	return nil
+}
+
+// AsNumber returns the result of a rollup of type number. It reports false
+// for other types and empty results.
+func (r RollupValue) AsNumber() (float64, bool) {
+	if r.Type != RollupTypeNumber || r.Number == nil {
+		return 0, false
+	}
+	return *r.Number, true
+}
+
+// AsDate returns the result of a rollup of type date. It reports false for
+// other types and empty results.
+func (r RollupValue) AsDate() (DateObject, bool) {
+	if r.Type != RollupTypeDate || r.Date == nil {
+		return DateObject{}, false
+	}
+	return *r.Date, true
+}
+
+// AsArray returns the values of a rollup of type array, nil for other types
+func (r RollupValue) AsArray() []Property {
+	if r.Type != RollupTypeArray {
+		return nil
+	}
+	return r.Array
+}
+
 type CreatedTimeProperty struct {
-	ID          ObjectID     `json:"id,omitempty"`
-	Type        PropertyType `json:"type"`
-	CreatedTime interface{}  `json:"created_time"`
+	ID          PropertyID   `json:"id,omitempty"`
+	Type        PropertyType `json:"type,omitempty"`
+	CreatedTime time.Time    `json:"created_time"`
 }
 
 func (p CreatedTimeProperty) GetType() PropertyType {
@@ -259,9 +765,9 @@ func (p CreatedTimeProperty) GetType() PropertyType {
 }
 
 type CreatedByProperty struct {
-	ID        ObjectID     `json:"id"`
-	Type      PropertyType `json:"type"`
-	CreatedBy interface{}  `json:"created_by"`
+	ID        PropertyID   `json:"id,omitempty"`
+	Type      PropertyType `json:"type,omitempty"`
+	CreatedBy User         `json:"created_by"`
 }
 
 func (p CreatedByProperty) GetType() PropertyType {
@@ -269,9 +775,9 @@ func (p CreatedByProperty) GetType() PropertyType {
 }
 
 type LastEditedTimeProperty struct {
-	ID             ObjectID     `json:"id"`
-	Type           PropertyType `json:"type"`
-	LastEditedTime interface{}  `json:"last_edited_time"`
+	ID             PropertyID   `json:"id,omitempty"`
+	Type           PropertyType `json:"type,omitempty"`
+	LastEditedTime time.Time    `json:"last_edited_time"`
 }
 
 func (p LastEditedTimeProperty) GetType() PropertyType {
@@ -279,19 +785,51 @@ func (p LastEditedTimeProperty) GetType() PropertyType {
 }
 
 type LastEditedByProperty struct {
-	ID           ObjectID     `json:"id"`
-	Type         PropertyType `json:"type"`
-	LastEditedBy interface{}  `json:"last_edited_by"`
+	ID           PropertyID   `json:"id,omitempty"`
+	Type         PropertyType `json:"type,omitempty"`
+	LastEditedBy User         `json:"last_edited_by"`
 }
 
 func (p LastEditedByProperty) GetType() PropertyType {
 	return p.Type
 }
 
+// UnknownProperty holds a property of a type this library does not support
+// yet. It keeps the original JSON and marshals back to it unchanged.
+type UnknownProperty struct {
+	ID   PropertyID      `json:"id,omitempty"`
+	Type PropertyType    `json:"type"`
+	Raw  json.RawMessage `json:"-"`
+}
+
+func (p UnknownProperty) GetType() PropertyType {
+	return p.Type
+}
+
+func (p *UnknownProperty) UnmarshalJSON(data []byte) error {
+	type property UnknownProperty
+	var tmp property
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*p = UnknownProperty(tmp)
+	p.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+func (p UnknownProperty) MarshalJSON() ([]byte, error) {
+	if p.Raw != nil {
+		return p.Raw, nil
+	}
+	type property UnknownProperty
+	return json.Marshal(property(p))
+}
+
+// Properties are the property values of a page keyed by name
 type Properties map[string]Property
 
 func (p *Properties) UnmarshalJSON(data []byte) error {
-	var raw map[string]interface{}
+	var raw map[string]json.RawMessage
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
@@ -299,97 +837,78 @@ func (p *Properties) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
-
 	*p = props
 	return nil
 }
 
-func parseProperties(raw map[string]interface{}) (map[string]Property, error) {
-	result := make(map[string]Property)
-	for k, v := range raw {
-		var p Property
-		switch rawProperty := v.(type) {
-		case map[string]interface{}:
-			switch PropertyType(rawProperty["type"].(string)) {
-			case PropertyTypeTitle:
-				switch v.(map[string]interface{})["title"].(type) {
-				case map[string]interface{}:
-					p = &DatabaseTitleProperty{}
-				default:
-					p = &PageTitleProperty{}
-				}
-			case PropertyTypeRichText:
-				switch v.(map[string]interface{})["rich_text"].(type) {
-				case map[string]interface{}:
-					p = &EmptyRichTextProperty{}
-				default:
-					p = &RichTextProperty{}
-				}
-			case PropertyTypeSelect:
-				selectMap, ok := v.(map[string]interface{})["select"].(map[string]interface{})
-				if !ok {
-					return nil, errors.Errorf("an error occured while parsing property type: %s", rawProperty)
-				}
-				_, found := selectMap["options"]
-				if found {
-					p = &SelectProperty{}
-				} else {
-					p = &SelectOptionProperty{}
-				}
-			case PropertyTypeMultiSelect:
-				switch v.(map[string]interface{})["multi_select"].(type) {
-				case map[string]interface{}:
-					p = &MultiSelectProperty{}
-				default:
-					p = &MultiSelectOptionsProperty{}
-				}
-			case PropertyTypeNumber:
-				p = &NumberProperty{}
-			case PropertyTypeCheckbox:
-				p = &CheckboxProperty{}
-			case PropertyTypeEmail:
-				p = &EmailProperty{}
-			case PropertyTypeURL:
-				p = &URLProperty{}
-			case PropertyTypeFile:
-				p = &FileProperty{}
-			case PropertyTypePhoneNumber:
-				p = PhoneNumberProperty{}
-			case PropertyTypeFormula:
-				p = &FormulaProperty{}
-			case PropertyTypeDate:
-				p = &DateProperty{}
-			case PropertyTypeRelation:
-				p = &RelationProperty{}
-			case PropertyTypeRollup:
-				p = &RollupProperty{}
-			case PropertyTypePeople:
-				p = &PeopleProperty{}
-			case PropertyTypeCreatedTime:
-				p = &CreatedTimeProperty{}
-			case PropertyTypeCreatedBy:
-				p = &CreatedByProperty{}
-			case PropertyTypeLastEditedTime:
-				p = &LastEditedTimeProperty{}
-			case PropertyTypeLastEditedBy:
-				p = &LastEditedByProperty{}
-			default:
-				return nil, errors.New(fmt.Sprintf("unsupported property type: %s", rawProperty["type"].(string)))
-			}
-			b, err := json.Marshal(rawProperty)
-			if err != nil {
-				return nil, err
-			}
-
-			if err = json.Unmarshal(b, &p); err != nil {
-				return nil, err
-			}
-
-			result[k] = p
-		default:
-			return nil, errors.New(fmt.Sprintf("unsupported property format %T", v))
+func parseProperties(raw map[string]json.RawMessage) (Properties, error) {
+	result := make(Properties)
+	for k, data := range raw {
+		p, err := parseProperty(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unsupported property format of %s", k)
 		}
+		result[k] = p
 	}
-
 	return result, nil
 }
+
+func parseProperty(data json.RawMessage) (Property, error) {
+	var p Property
+	switch propertyTypeOf(data) {
+	case PropertyTypeTitle:
+		p = &TitleProperty{}
+	case PropertyTypeRichText:
+		p = &RichTextProperty{}
+	case PropertyTypeNumber:
+		p = &NumberProperty{}
+	case PropertyTypeSelect:
+		p = &SelectProperty{}
+	case PropertyTypeMultiSelect:
+		p = &MultiSelectProperty{}
+	case PropertyTypeDate:
+		p = &DateProperty{}
+	case PropertyTypePeople:
+		p = &PeopleProperty{}
+	case PropertyTypeFiles:
+		p = &FilesProperty{}
+	case PropertyTypeCheckbox:
+		p = &CheckboxProperty{}
+	case PropertyTypeURL:
+		p = &URLProperty{}
+	case PropertyTypeEmail:
+		p = &EmailProperty{}
+	case PropertyTypePhoneNumber:
+		p = &PhoneNumberProperty{}
+	case PropertyTypeFormula:
+		p = &FormulaProperty{}
+	case PropertyTypeRelation:
+		p = &RelationProperty{}
+	case PropertyTypeRollup:
+		p = &RollupProperty{}
+	case PropertyTypeCreatedTime:
+		p = &CreatedTimeProperty{}
+	case PropertyTypeCreatedBy:
+		p = &CreatedByProperty{}
+	case PropertyTypeLastEditedTime:
+		p = &LastEditedTimeProperty{}
+	case PropertyTypeLastEditedBy:
+		p = &LastEditedByProperty{}
+	default:
+		p = &UnknownProperty{}
+	}
+
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// propertyTypeOf reads the type of a raw property, empty if there is none
+func propertyTypeOf(data json.RawMessage) PropertyType {
+	var header struct {
+		Type PropertyType `json:"type"`
+	}
+	_ = json.Unmarshal(data, &header)
+	return header.Type
+}
@@ -0,0 +1,209 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	// tokenIdent is a bare word, e.g. a property name or a keyword
+	tokenIdent
+	// tokenQuotedIdent is a property name in backticks
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of query"
+	case tokenIdent, tokenQuotedIdent:
+		return "name"
+	case tokenString:
+		return "string"
+	case tokenNumber:
+		return "number"
+	case tokenOperator:
+		return "operator"
+	case tokenLParen:
+		return "("
+	case tokenRParen:
+		return ")"
+	case tokenComma:
+		return ","
+	}
+	return "token"
+}
+
+// Pos is a position in the query, both line and column start at 1. Columns
+// count runes.
+type Pos struct {
+	Line   int
+	Column int
+}
+
+type token struct {
+	kind tokenKind
+	// text is the unquoted value of the token
+	text string
+	pos  Pos
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return t.kind.String()
+	case tokenString:
+		return fmt.Sprintf("%q", t.text)
+	case tokenQuotedIdent:
+		return "`" + t.text + "`"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// is reports whether t is the keyword kw, ignoring case
+func (t token) is(kw string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, kw)
+}
+
+type lexer struct {
+	src  []rune
+	i    int
+	line int
+	col  int
+}
+
+func lex(src string) ([]token, error) {
+	l := &lexer{src: []rune(src), line: 1, col: 1}
+	var tokens []token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+		if t.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) peek() rune {
+	if l.i >= len(l.src) {
+		return 0
+	}
+	return l.src[l.i]
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.i]
+	l.i++
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+func (l *lexer) pos() Pos {
+	return Pos{Line: l.line, Column: l.col}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.i < len(l.src) && unicode.IsSpace(l.peek()) {
+		l.advance()
+	}
+	pos := l.pos()
+	if l.i >= len(l.src) {
+		return token{kind: tokenEOF, pos: pos}, nil
+	}
+
+	r := l.peek()
+	switch {
+	case r == '(':
+		l.advance()
+		return token{kind: tokenLParen, text: "(", pos: pos}, nil
+	case r == ')':
+		l.advance()
+		return token{kind: tokenRParen, text: ")", pos: pos}, nil
+	case r == ',':
+		l.advance()
+		return token{kind: tokenComma, text: ",", pos: pos}, nil
+	case r == '"':
+		s, err := l.quoted('"')
+		return token{kind: tokenString, text: s, pos: pos}, err
+	case r == '`':
+		s, err := l.quoted('`')
+		if err == nil && s == "" {
+			err = errorf(pos, "empty property name")
+		}
+		return token{kind: tokenQuotedIdent, text: s, pos: pos}, err
+	case strings.ContainsRune("=!<>", r):
+		l.advance()
+		op := string(r)
+		if l.peek() == '=' {
+			op += string(l.advance())
+		}
+		if op == "!" {
+			return token{}, errorf(pos, "unexpected character '!', did you mean '!='")
+		}
+		return token{kind: tokenOperator, text: op, pos: pos}, nil
+	case r == '-' || unicode.IsDigit(r):
+		return l.number(pos)
+	case r == '_' || unicode.IsLetter(r):
+		start := l.i
+		for l.i < len(l.src) && (l.peek() == '_' || unicode.IsLetter(l.peek()) || unicode.IsDigit(l.peek())) {
+			l.advance()
+		}
+		return token{kind: tokenIdent, text: string(l.src[start:l.i]), pos: pos}, nil
+	}
+	return token{}, errorf(pos, "unexpected character %q", r)
+}
+
+// quoted reads a string delimited by quote. Backslash escapes the quote and
+// the backslash itself.
+func (l *lexer) quoted(quote rune) (string, error) {
+	start := l.pos()
+	l.advance()
+	var b strings.Builder
+	for l.i < len(l.src) {
+		r := l.advance()
+		switch r {
+		case quote:
+			return b.String(), nil
+		case '\\':
+			if l.i < len(l.src) && (l.peek() == quote || l.peek() == '\\') {
+				r = l.advance()
+			}
+		}
+		b.WriteRune(r)
+	}
+	return "", errorf(start, "unterminated %c", quote)
+}
+
+func (l *lexer) number(pos Pos) (token, error) {
+	start := l.i
+	if l.peek() == '-' {
+		l.advance()
+	}
+	digits := 0
+	for l.i < len(l.src) && (unicode.IsDigit(l.peek()) || l.peek() == '.') {
+		l.advance()
+		digits++
+	}
+	if digits == 0 {
+		return token{}, errorf(pos, "expected a number after '-'")
+	}
+	return token{kind: tokenNumber, text: string(l.src[start:l.i]), pos: pos}, nil
+}
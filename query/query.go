@@ -0,0 +1,622 @@
+// Package query compiles a small text query language into database queries:
+//
+//	Status = "Done" AND (Priority > 2 OR Tags contains "urgent") ORDER BY Due desc
+//
+// A condition compares a property with a value. Property names with spaces
+// or names clashing with keywords are written in backticks, e.g. `Due date`.
+// Keywords are case insensitive. The operators are
+//
+//	=  !=  >  <  >=  <=
+//	contains, not contains, starts with, ends with
+//	before, after, on or before, on or after
+//	past week, past month, past year, next week, next month, next year
+//	is empty, is not empty
+//
+// Values are double quoted strings, numbers, true or false. Dates are strings
+// like "2021-06-01" or "2021-06-01T10:00:00Z". AND binds tighter than OR and
+// parentheses group conditions. ORDER BY takes a comma separated list of
+// properties, each optionally followed by asc or desc. created_time and
+// last_edited_time sort by the page timestamps unless the database has a
+// property of that name.
+//
+// When a database is passed to Parse, property names are checked against its
+// schema and the property type picks the condition, e.g. = on a select
+// property becomes a select condition. Without a database the condition is
+// derived from the value: numbers compare numbers, true and false checkboxes,
+// date operators and date strings like "2021-06-01" dates, and starts with
+// and ends with text. Other conditions, like = "Done" or is empty, fit
+// several property types and fail to parse without the database.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// Error is a syntax or type error in a query
+type Error struct {
+	Pos
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+func errorf(pos Pos, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// Parse compiles src into a query request. db is optional and used to check
+// property names and types. Errors are of type *Error.
+func Parse(src string, db *notionapi.Database) (*notionapi.DatabaseQueryRequest, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if db != nil {
+		p.properties = db.Properties
+	}
+
+	request := &notionapi.DatabaseQueryRequest{}
+	if !p.peek().is("order") && p.peek().kind != tokenEOF {
+		if request.Filter, err = p.or(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek().is("order") {
+		if request.Sorts, err = p.orderBy(); err != nil {
+			return nil, err
+		}
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, errorf(t.pos, "unexpected %s, expected AND, OR or ORDER BY", t)
+	}
+	return request, nil
+}
+
+type parser struct {
+	tokens     []token
+	i          int
+	properties notionapi.PropertyConfigs
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+// accept consumes the keywords if they come next
+func (p *parser) accept(keywords ...string) bool {
+	for i, kw := range keywords {
+		if p.i+i >= len(p.tokens) || !p.tokens[p.i+i].is(kw) {
+			return false
+		}
+	}
+	p.i += len(keywords)
+	return true
+}
+
+func (p *parser) expect(keyword string) error {
+	if t := p.peek(); !p.accept(keyword) {
+		return errorf(t.pos, "unexpected %s, expected %s", t, strings.ToUpper(keyword))
+	}
+	return nil
+}
+
+func (p *parser) or() (notionapi.Filter, error) {
+	first, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	filters := []notionapi.Filter{first}
+	for p.accept("or") {
+		f, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	if len(filters) == 1 {
+		return first, nil
+	}
+	return notionapi.OrCompoundFilter(filters), nil
+}
+
+func (p *parser) and() (notionapi.Filter, error) {
+	first, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	filters := []notionapi.Filter{first}
+	for p.accept("and") {
+		f, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	if len(filters) == 1 {
+		return first, nil
+	}
+	return notionapi.AndCompoundFilter(filters), nil
+}
+
+func (p *parser) operand() (notionapi.Filter, error) {
+	if t := p.peek(); t.kind == tokenLParen {
+		p.next()
+		f, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, errorf(t.pos, "unexpected %s, expected )", t)
+		}
+		return f, nil
+	}
+	return p.condition()
+}
+
+// property reads a property name
+func (p *parser) property() (token, error) {
+	t := p.next()
+	if t.kind != tokenIdent && t.kind != tokenQuotedIdent {
+		return t, errorf(t.pos, "unexpected %s, expected a property name", t)
+	}
+	if t.kind == tokenIdent && isKeyword(t.text) {
+		return t, errorf(t.pos, "unexpected keyword %s, quote property names like this in backticks", t)
+	}
+	return t, nil
+}
+
+var keywords = []string{"and", "or", "order", "by", "asc", "desc", "not", "is", "empty", "contains",
+	"starts", "ends", "with", "before", "after", "on", "past", "next", "true", "false"}
+
+func isKeyword(s string) bool {
+	for _, kw := range keywords {
+		if strings.EqualFold(s, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// operator is a comparison like "=" or "on_or_after". Operators without a
+// value are the relative dates and the empty checks.
+type operator struct {
+	name     string
+	pos      Pos
+	hasValue bool
+}
+
+func (p *parser) operator() (operator, error) {
+	t := p.peek()
+	op := operator{pos: t.pos, hasValue: true}
+	switch {
+	case t.kind == tokenOperator:
+		p.next()
+		op.name = t.text
+	case p.accept("contains"):
+		op.name = "contains"
+	case p.accept("not", "contains"):
+		op.name = "does_not_contain"
+	case p.accept("starts", "with"):
+		op.name = "starts_with"
+	case p.accept("ends", "with"):
+		op.name = "ends_with"
+	case p.accept("before"):
+		op.name = "before"
+	case p.accept("after"):
+		op.name = "after"
+	case p.accept("on", "or", "before"):
+		op.name = "on_or_before"
+	case p.accept("on", "or", "after"):
+		op.name = "on_or_after"
+	case p.accept("is", "empty"):
+		op.name, op.hasValue = "is_empty", false
+	case p.accept("is", "not", "empty"):
+		op.name, op.hasValue = "is_not_empty", false
+	case t.is("past") || t.is("next"):
+		p.next()
+		period := p.next()
+		if !period.is("week") && !period.is("month") && !period.is("year") {
+			return op, errorf(period.pos, "unexpected %s, expected week, month or year", period)
+		}
+		op.name, op.hasValue = strings.ToLower(t.text+"_"+period.text), false
+	default:
+		return op, errorf(t.pos, "unexpected %s, expected an operator", t)
+	}
+	return op, nil
+}
+
+func (p *parser) condition() (notionapi.Filter, error) {
+	name, err := p.property()
+	if err != nil {
+		return nil, err
+	}
+	op, err := p.operator()
+	if err != nil {
+		return nil, err
+	}
+	var v token
+	if op.hasValue {
+		v = p.next()
+		switch {
+		case v.kind == tokenString || v.kind == tokenNumber:
+		case v.is("true") || v.is("false"):
+		default:
+			return nil, errorf(v.pos, "unexpected %s, expected a value", v)
+		}
+	}
+
+	propertyType, err := p.propertyType(name)
+	if err != nil {
+		return nil, err
+	}
+	c := &comparison{property: name, op: op, value: v}
+	if propertyType == notionapi.PropertyTypeFormula {
+		return c.formula()
+	}
+	if propertyType == "" {
+		if propertyType = c.inferType(); propertyType == "" {
+			return nil, errorf(name.pos, "type of property %q is unknown, pass the database to use %s", name.text, op.name)
+		}
+	}
+	return c.build(propertyType)
+}
+
+func (p *parser) propertyType(name token) (notionapi.PropertyType, error) {
+	if p.properties == nil {
+		return "", nil
+	}
+	property, ok := p.properties[name.text]
+	if !ok {
+		return "", errorf(name.pos, "unknown property %q", name.text)
+	}
+	return property.GetType(), nil
+}
+
+func (p *parser) orderBy() ([]notionapi.SortObject, error) {
+	p.next()
+	if err := p.expect("by"); err != nil {
+		return nil, err
+	}
+	var sorts []notionapi.SortObject
+	for {
+		name, err := p.property()
+		if err != nil {
+			return nil, err
+		}
+		sort := notionapi.SortObject{Property: name.text, Direction: notionapi.SortOrderASC}
+		timestamp := notionapi.TimestampType(strings.ToLower(name.text))
+		_, isProperty := p.properties[name.text]
+		switch {
+		case name.kind == tokenIdent && !isProperty &&
+			(timestamp == notionapi.TimestampCreated || timestamp == notionapi.TimestampLastEdited):
+			sort.Property, sort.Timestamp = "", timestamp
+		case p.properties != nil && !isProperty:
+			return nil, errorf(name.pos, "unknown property %q", name.text)
+		}
+
+		switch {
+		case p.accept("asc"):
+		case p.accept("desc"):
+			sort.Direction = notionapi.SortOrderDESC
+		}
+		sorts = append(sorts, sort)
+
+		if p.peek().kind != tokenComma {
+			return sorts, nil
+		}
+		p.next()
+	}
+}
+
+// comparison is a parsed condition before it is turned into a filter
+type comparison struct {
+	property token
+	op       operator
+	value    token
+}
+
+var dateOperators = map[string]bool{
+	"before": true, "after": true, "on_or_before": true, "on_or_after": true,
+	"past_week": true, "past_month": true, "past_year": true,
+	"next_week": true, "next_month": true, "next_year": true,
+}
+
+// inferType picks the property type of a condition without a schema. It
+// returns "" if the condition fits several types, e.g. = "Done" fits text and
+// select properties.
+func (c *comparison) inferType() notionapi.PropertyType {
+	switch {
+	case dateOperators[c.op.name]:
+		return notionapi.PropertyTypeDate
+	case c.value.kind == tokenNumber:
+		return notionapi.PropertyTypeNumber
+	case c.value.is("true") || c.value.is("false"):
+		return notionapi.PropertyTypeCheckbox
+	case c.value.kind == tokenString:
+		if _, err := parseDate(c.value.text); err == nil {
+			return notionapi.PropertyTypeDate
+		}
+	}
+	if c.op.name == "starts_with" || c.op.name == "ends_with" {
+		return notionapi.PropertyTypeRichText
+	}
+	return ""
+}
+
+// formula builds a condition on a formula property, the type of the result
+// is derived from the value
+func (c *comparison) formula() (notionapi.Filter, error) {
+	t := c.inferType()
+	if t == "" {
+		// formulas result in text, numbers, checkboxes or dates
+		t = notionapi.PropertyTypeRichText
+	}
+	f, err := c.build(t)
+	if err != nil {
+		return nil, err
+	}
+	pf := f.(*notionapi.PropertyFilter)
+	formula := &notionapi.FormulaFilterCondition{Text: pf.Text, Number: pf.Number, Checkbox: pf.Checkbox, Date: pf.Date}
+	return &notionapi.PropertyFilter{Property: pf.Property, Formula: formula}, nil
+}
+
+func (c *comparison) build(t notionapi.PropertyType) (notionapi.Filter, error) {
+	f := &notionapi.PropertyFilter{Property: c.property.text}
+	var err error
+	switch t {
+	case notionapi.PropertyTypeTitle, notionapi.PropertyTypeRichText, notionapi.PropertyTypeURL,
+		notionapi.PropertyTypeEmail, notionapi.PropertyTypePhoneNumber:
+		f.Text, err = c.text(t)
+	case notionapi.PropertyTypeNumber:
+		f.Number, err = c.number()
+	case notionapi.PropertyTypeCheckbox:
+		f.Checkbox, err = c.checkbox()
+	case notionapi.PropertyTypeSelect:
+		f.Select, err = c.selectCondition()
+	case notionapi.PropertyTypeMultiSelect:
+		var list listCondition
+		if list, err = c.list(t); err == nil {
+			f.MultiSelect = &notionapi.MultiSelectFilterCondition{
+				Contains: list.contains, DoesNotContain: list.doesNotContain, IsEmpty: list.isEmpty, IsNotEmpty: list.isNotEmpty,
+			}
+		}
+	case notionapi.PropertyTypePeople, notionapi.PropertyTypeCreatedBy, notionapi.PropertyTypeLastEditedBy:
+		var list listCondition
+		if list, err = c.list(t); err == nil {
+			f.People = &notionapi.PeopleFilterCondition{
+				Contains: list.contains, DoesNotContain: list.doesNotContain, IsEmpty: list.isEmpty, IsNotEmpty: list.isNotEmpty,
+			}
+		}
+	case notionapi.PropertyTypeRelation:
+		var list listCondition
+		if list, err = c.list(t); err == nil {
+			f.Relation = &notionapi.RelationFilterCondition{
+				Contains: list.contains, DoesNotContain: list.doesNotContain, IsEmpty: list.isEmpty, IsNotEmpty: list.isNotEmpty,
+			}
+		}
+	case notionapi.PropertyTypeDate, notionapi.PropertyTypeCreatedTime, notionapi.PropertyTypeLastEditedTime:
+		f.Date, err = c.date()
+	case notionapi.PropertyTypeFiles:
+		switch c.op.name {
+		case "is_empty":
+			f.Files = &notionapi.FilesFilterCondition{IsEmpty: true}
+		case "is_not_empty":
+			f.Files = &notionapi.FilesFilterCondition{IsNotEmpty: true}
+		default:
+			err = c.invalidOperator(t)
+		}
+	default:
+		err = errorf(c.property.pos, "can not filter %s property %q", t, c.property.text)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (c *comparison) invalidOperator(t notionapi.PropertyType) error {
+	return errorf(c.op.pos, "operator %s is not valid for %s property %q", c.op.name, t, c.property.text)
+}
+
+func (c *comparison) stringValue(t notionapi.PropertyType) (string, error) {
+	if c.value.kind != tokenString {
+		return "", errorf(c.value.pos, "%s property %q needs a string, got %s", t, c.property.text, c.value)
+	}
+	if c.value.text == "" {
+		return "", errorf(c.value.pos, "empty string, use is empty instead")
+	}
+	return c.value.text, nil
+}
+
+func (c *comparison) text(t notionapi.PropertyType) (*notionapi.TextFilterCondition, error) {
+	switch c.op.name {
+	case "is_empty":
+		return &notionapi.TextFilterCondition{IsEmpty: true}, nil
+	case "is_not_empty":
+		return &notionapi.TextFilterCondition{IsNotEmpty: true}, nil
+	}
+	s, err := c.stringValue(t)
+	if err != nil {
+		return nil, err
+	}
+	switch c.op.name {
+	case "=":
+		return &notionapi.TextFilterCondition{Equals: s}, nil
+	case "!=":
+		return &notionapi.TextFilterCondition{DoesNotEqual: s}, nil
+	case "contains":
+		return &notionapi.TextFilterCondition{Contains: s}, nil
+	case "does_not_contain":
+		return &notionapi.TextFilterCondition{DoesNotContain: s}, nil
+	case "starts_with":
+		return &notionapi.TextFilterCondition{StartsWith: s}, nil
+	case "ends_with":
+		return &notionapi.TextFilterCondition{EndsWith: s}, nil
+	}
+	return nil, c.invalidOperator(t)
+}
+
+func (c *comparison) number() (*notionapi.NumberFilterCondition, error) {
+	switch c.op.name {
+	case "is_empty":
+		return &notionapi.NumberFilterCondition{IsEmpty: true}, nil
+	case "is_not_empty":
+		return &notionapi.NumberFilterCondition{IsNotEmpty: true}, nil
+	}
+	if c.value.kind != tokenNumber {
+		if c.op.hasValue {
+			return nil, errorf(c.value.pos, "number property %q needs a number, got %s", c.property.text, c.value)
+		}
+		return nil, c.invalidOperator(notionapi.PropertyTypeNumber)
+	}
+	n, err := strconv.ParseFloat(c.value.text, 64)
+	if err != nil {
+		return nil, errorf(c.value.pos, "invalid number %s", c.value.text)
+	}
+	switch c.op.name {
+	case "=":
+		return new(notionapi.NumberFilterCondition).SetEquals(n), nil
+	case "!=":
+		return new(notionapi.NumberFilterCondition).SetDoesNotEqual(n), nil
+	case ">":
+		return new(notionapi.NumberFilterCondition).SetGreaterThan(n), nil
+	case "<":
+		return new(notionapi.NumberFilterCondition).SetLessThan(n), nil
+	case ">=":
+		return new(notionapi.NumberFilterCondition).SetGreaterThanOrEqualTo(n), nil
+	case "<=":
+		return new(notionapi.NumberFilterCondition).SetLessThanOrEqualTo(n), nil
+	}
+	return nil, c.invalidOperator(notionapi.PropertyTypeNumber)
+}
+
+func (c *comparison) checkbox() (*notionapi.CheckboxFilterCondition, error) {
+	if c.op.name != "=" && c.op.name != "!=" {
+		return nil, c.invalidOperator(notionapi.PropertyTypeCheckbox)
+	}
+	if !c.value.is("true") && !c.value.is("false") {
+		return nil, errorf(c.value.pos, "checkbox property %q needs true or false, got %s", c.property.text, c.value)
+	}
+	v := c.value.is("true")
+	if c.op.name == "=" {
+		return new(notionapi.CheckboxFilterCondition).SetEquals(v), nil
+	}
+	return new(notionapi.CheckboxFilterCondition).SetDoesNotEqual(v), nil
+}
+
+func (c *comparison) selectCondition() (*notionapi.SelectFilterCondition, error) {
+	switch c.op.name {
+	case "is_empty":
+		return &notionapi.SelectFilterCondition{IsEmpty: true}, nil
+	case "is_not_empty":
+		return &notionapi.SelectFilterCondition{IsNotEmpty: true}, nil
+	case "=", "!=":
+		s, err := c.stringValue(notionapi.PropertyTypeSelect)
+		if err != nil {
+			return nil, err
+		}
+		if c.op.name == "=" {
+			return &notionapi.SelectFilterCondition{Equals: s}, nil
+		}
+		return &notionapi.SelectFilterCondition{DoesNotEqual: s}, nil
+	}
+	return nil, c.invalidOperator(notionapi.PropertyTypeSelect)
+}
+
+// listCondition is the condition shared by multi_select, people and relation
+type listCondition struct {
+	contains, doesNotContain string
+	isEmpty, isNotEmpty      bool
+}
+
+func (c *comparison) list(t notionapi.PropertyType) (listCondition, error) {
+	switch c.op.name {
+	case "is_empty":
+		return listCondition{isEmpty: true}, nil
+	case "is_not_empty":
+		return listCondition{isNotEmpty: true}, nil
+	case "contains", "does_not_contain":
+		s, err := c.stringValue(t)
+		if err != nil {
+			return listCondition{}, err
+		}
+		if c.op.name == "contains" {
+			return listCondition{contains: s}, nil
+		}
+		return listCondition{doesNotContain: s}, nil
+	}
+	return listCondition{}, c.invalidOperator(t)
+}
+
+func (c *comparison) date() (*notionapi.DateFilterCondition, error) {
+	switch c.op.name {
+	case "is_empty":
+		return &notionapi.DateFilterCondition{IsEmpty: true}, nil
+	case "is_not_empty":
+		return &notionapi.DateFilterCondition{IsNotEmpty: true}, nil
+	case "past_week":
+		return &notionapi.DateFilterCondition{PastWeek: &struct{}{}}, nil
+	case "past_month":
+		return &notionapi.DateFilterCondition{PastMonth: &struct{}{}}, nil
+	case "past_year":
+		return &notionapi.DateFilterCondition{PastYear: &struct{}{}}, nil
+	case "next_week":
+		return &notionapi.DateFilterCondition{NextWeek: &struct{}{}}, nil
+	case "next_month":
+		return &notionapi.DateFilterCondition{NextMonth: &struct{}{}}, nil
+	case "next_year":
+		return &notionapi.DateFilterCondition{NextYear: &struct{}{}}, nil
+	}
+
+	s, err := c.stringValue(notionapi.PropertyTypeDate)
+	if err != nil {
+		return nil, err
+	}
+	date, err := parseDate(s)
+	if err != nil {
+		return nil, errorf(c.value.pos, "invalid date %q, use 2006-01-02 or RFC 3339", s)
+	}
+	switch c.op.name {
+	case "=":
+		return &notionapi.DateFilterCondition{Equals: date}, nil
+	case "<", "before":
+		return &notionapi.DateFilterCondition{Before: date}, nil
+	case ">", "after":
+		return &notionapi.DateFilterCondition{After: date}, nil
+	case "<=", "on_or_before":
+		return &notionapi.DateFilterCondition{OnOrBefore: date}, nil
+	case ">=", "on_or_after":
+		return &notionapi.DateFilterCondition{OnOrAfter: date}, nil
+	}
+	return nil, c.invalidOperator(notionapi.PropertyTypeDate)
+}
+
+// parseDate reads a date-only or RFC 3339 date
+func parseDate(s string) (*notionapi.DateObject, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err == nil {
+		start := notionapi.Date(t)
+		return &notionapi.DateObject{Start: &start, DateOnly: true}, nil
+	}
+	if t, err = time.Parse(time.RFC3339, s); err != nil {
+		return nil, err
+	}
+	start := notionapi.Date(t)
+	return &notionapi.DateObject{Start: &start}, nil
+}
@@ -0,0 +1,180 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/jomei/notionapi/query"
+)
+
+func loadDatabase(t *testing.T) *notionapi.Database {
+	t.Helper()
+	data, err := ioutil.ReadFile("testdata/database.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var db notionapi.Database
+	if err := json.Unmarshal(data, &db); err != nil {
+		t.Fatal(err)
+	}
+	return &db
+}
+
+func TestParse(t *testing.T) {
+	db := loadDatabase(t)
+	tests := []struct {
+		name  string
+		query string
+		db    *notionapi.Database
+		want  string
+	}{
+		{
+			name:  "example from the docs",
+			query: `Status = "Done" AND (Priority > 2 OR Tags contains "urgent") ORDER BY Due desc`,
+			db:    db,
+			want: `{"sorts":[{"property":"Due","direction":"descending"}],"filter":{"and":[` +
+				`{"property":"Status","select":{"equals":"Done"}},` +
+				`{"or":[{"property":"Priority","number":{"greater_than":2}},{"property":"Tags","multi_select":{"contains":"urgent"}}]}]}}`,
+		},
+		{
+			name:  "and binds tighter than or",
+			query: `Done = true or Priority = 0 and Name starts with "Re"`,
+			db:    db,
+			want: `{"filter":{"or":[{"property":"Done","checkbox":{"equals":true}},{"and":[` +
+				`{"property":"Priority","number":{"equals":0}},{"property":"Name","text":{"starts_with":"Re"}}]}]}}`,
+		},
+		{
+			name:  "without schema",
+			query: `Name starts with "Re" AND Priority >= 2 AND Done != false AND Due before "2021-06-01"`,
+			want: `{"filter":{"and":[{"property":"Name","text":{"starts_with":"Re"}},` +
+				`{"property":"Priority","number":{"greater_than_or_equal_to":2}},` +
+				`{"property":"Done","checkbox":{"does_not_equal":false}},` +
+				`{"property":"Due","date":{"before":"2021-06-01"}}]}}`,
+		},
+		{
+			name:  "dates without schema",
+			query: `Due = "2021-06-01" or Due > "2021-06-01T10:00:00Z" or Name ends with "2021"`,
+			want: `{"filter":{"or":[{"property":"Due","date":{"equals":"2021-06-01"}},` +
+				`{"property":"Due","date":{"after":"2021-06-01T10:00:00Z"}},{"property":"Name","text":{"ends_with":"2021"}}]}}`,
+		},
+		{
+			name:  "quoted names and empty checks",
+			query: "`Parent tasks` is not empty and Owners is empty",
+			db:    db,
+			want: `{"filter":{"and":[{"property":"Parent tasks","relation":{"is_not_empty":true}},` +
+				`{"property":"Owners","people":{"is_empty":true}}]}}`,
+		},
+		{
+			name:  "dates",
+			query: `Due >= "2021-06-01T10:00:00Z" or Due next month or Created past week`,
+			db:    db,
+			want: `{"filter":{"or":[{"property":"Due","date":{"on_or_after":"2021-06-01T10:00:00Z"}},` +
+				`{"property":"Due","date":{"next_month":{}}},{"property":"Created","date":{"past_week":{}}}]}}`,
+		},
+		{
+			name:  "formula",
+			query: `Score > 10`,
+			db:    db,
+			want:  `{"filter":{"property":"Score","formula":{"number":{"greater_than":10}}}}`,
+		},
+		{
+			name:  "escaped string",
+			query: `Name contains "say \"hi\""`,
+			db:    db,
+			want:  `{"filter":{"property":"Name","text":{"contains":"say \"hi\""}}}`,
+		},
+		{
+			name:  "only sorts",
+			query: "order by Status, created_time DESC, Priority asc",
+			db:    db,
+			want: `{"sorts":[{"property":"Status","direction":"ascending"},{"timestamp":"created_time","direction":"descending"},` +
+				`{"property":"Priority","direction":"ascending"}]}`,
+		},
+		{
+			name:  "empty",
+			query: "  ",
+			want:  `{}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := query.Parse(tt.query, tt.db)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got.Filter != nil {
+				if err := got.Filter.Validate(); err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+			}
+			j, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(j) != tt.want {
+				t.Errorf("Parse() got = %s, want %s", j, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	db := loadDatabase(t)
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"unknown property", `Status = "Done" and Stat = "x"`, `1:21: unknown property "Stat"`},
+		{"invalid operator", `Tags = "urgent"`, `1:6: operator = is not valid for multi_select property "Tags"`},
+		{"wrong value type", `Priority > "high"`, `1:12: number property "Priority" needs a number, got "high"`},
+		{"missing value", `Status =`, `1:9: unexpected end of query, expected a value`},
+		{"missing parenthesis", "Done = true and\n  (Priority > 2", `2:16: unexpected end of query, expected )`},
+		{"unterminated string", "Name = \"abc", `1:8: unterminated "`},
+		{"unexpected character", "Name ~ \"abc\"", `1:6: unexpected character '~'`},
+		{"keyword as property", `empty = 1`, `1:1: unexpected keyword "empty", quote property names like this in backticks`},
+		{"invalid date", `Due before "tomorrow"`, `1:12: invalid date "tomorrow", use 2006-01-02 or RFC 3339`},
+		{"relative date period", `Due past day`, `1:10: unexpected "day", expected week, month or year`},
+		{"trailing tokens", `Done = true Priority > 1`, `1:13: unexpected "Priority", expected AND, OR or ORDER BY`},
+		{"order without by", `Done = true order Priority`, `1:19: unexpected "Priority", expected BY`},
+		{"unknown sort property", "Done = true\norder by Nope", `2:10: unknown property "Nope"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := query.Parse(tt.query, db)
+			if err == nil {
+				t.Fatal("Parse() error = nil")
+			}
+			var queryErr *query.Error
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("Parse() error = %T, want *query.Error", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Parse() error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_WithoutSchema(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"equals a string", `Status = "Done"`, `1:1: type of property "Status" is unknown, pass the database to use =`},
+		{"contains", `Done = true and Tags contains "urgent"`, `1:17: type of property "Tags" is unknown, pass the database to use contains`},
+		{"is empty", "`Parent tasks` is empty", `1:1: type of property "Parent tasks" is unknown, pass the database to use is_empty`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := query.Parse(tt.query, nil)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Parse() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package notionapi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting how often requests are sent.
+// It is safe for concurrent use and can be shared by several clients that
+// use the same integration token, see WithRateLimiter.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter that allows rps requests per second on
+// average and bursts of up to burst requests.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done. It fails right away
+// if the token would not become available before the ctx deadline.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if err := sleepContext(ctx, wait); err != nil {
+		l.cancel()
+		return fmt.Errorf("rate limit wait: %w", err)
+	}
+	return nil
+}
+
+// cancel returns a reserved token that was not used
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
+
+// WithRateLimit limits the client to rps requests per second with bursts of up
+// to burst requests. Notion allows about 3 requests per second per integration.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return WithRateLimiter(NewRateLimiter(rps, burst))
+}
+
+// WithRateLimiter makes the client take a token from l before every request.
+// Pass the same limiter to all clients that share an integration token.
+func WithRateLimiter(l *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = l
+	}
+}
+
+// rateLimitMiddleware takes a token from l before every call
+func rateLimitMiddleware(l *RateLimiter) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, call *Call) (interface{}, error) {
+			if err := l.Wait(ctx); err != nil {
+				return nil, err
+			}
+			return next.Do(ctx, call)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package notionapi_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jomei/notionapi"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("Wait", func(t *testing.T) {
+		l := notionapi.NewRateLimiter(20, 2)
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			if err := l.Wait(context.Background()); err != nil {
+				t.Fatalf("Wait() error = %v", err)
+			}
+		}
+		// two tokens come from the burst, the other two take 50ms each
+		if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+			t.Errorf("Wait() took %v, want at least 90ms", elapsed)
+		}
+	})
+
+	t.Run("stops waiting when the context is cancelled", func(t *testing.T) {
+		l := notionapi.NewRateLimiter(0.1, 1)
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+		err := l.Wait(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Wait() error = %v, want %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("is shared between clients", func(t *testing.T) {
+		l := notionapi.NewRateLimiter(20, 1)
+		c := newMockedClient(t, "testdata/user_get.json", http.StatusOK)
+		first := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c), notionapi.WithRateLimiter(l))
+		second := notionapi.NewClient("some_token", notionapi.WithHTTPClient(c), notionapi.WithRateLimiter(l))
+
+		start := time.Now()
+		for _, client := range []*notionapi.Client{first, second, first} {
+			if _, err := client.User.Get(context.Background(), "some_id"); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+			t.Errorf("requests took %v, want at least 90ms", elapsed)
+		}
+	})
+}
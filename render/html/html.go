@@ -0,0 +1,388 @@
+// Package html renders blocks and rich text as HTML fragments:
+//
+//	blocks, err := client.Block.GetTree(ctx, notionapi.BlockID(page.ID), nil)
+//	if err != nil {
+//		return err
+//	}
+//	out := html.Render(blocks, &html.Options{
+//		PageURL: func(id notionapi.ObjectID) string { return "/wiki/" + id.String() },
+//	})
+//
+// Text is escaped and links with schemes other than http, https, mailto and
+// tel are dropped, so the output can be embedded as is. Colors become the
+// classes "notion-<color>" and "notion-<color>-background". Headings get the
+// ID of their block as id attribute, which the table of contents links to.
+//
+// The output only depends on the blocks and options, so it can be compared
+// with golden files.
+package html
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// Options configures the rendering
+type Options struct {
+	// PageURL returns the link target of child pages, links to pages, page
+	// mentions and text linking to notion.so pages or to /<id>. The default
+	// links to notion.so.
+	PageURL func(id notionapi.ObjectID) string
+	// ImageURL rewrites the source of images and icons, e.g. to point to
+	// copies of files whose Notion URLs expire
+	ImageURL func(url string) string
+}
+
+func (o *Options) pageURL(id notionapi.ObjectID) string {
+	if o != nil && o.PageURL != nil {
+		return o.PageURL(id)
+	}
+	return "https://www.notion.so/" + strings.ReplaceAll(id.String(), "-", "")
+}
+
+func (o *Options) imageURL(u string) string {
+	if o != nil && o.ImageURL != nil {
+		return o.ImageURL(u)
+	}
+	return u
+}
+
+// Render renders blocks whose children are already fetched, e.g. by
+// BlockService.GetTree
+func Render(blocks []notionapi.Block, opts *Options) []byte {
+	r := &renderer{opts: opts, headings: headings(blocks)}
+	r.blocks(blocks)
+	return []byte(r.b.String())
+}
+
+// RenderRichText renders rich text as inline HTML
+func RenderRichText(p notionapi.Paragraph, opts *Options) []byte {
+	r := &renderer{opts: opts}
+	r.inline(p)
+	return []byte(r.b.String())
+}
+
+type renderer struct {
+	opts     *Options
+	headings []notionapi.Block
+	b        strings.Builder
+}
+
+func (r *renderer) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&r.b, format, args...)
+}
+
+// blocks renders sibling blocks, grouping neighbouring list items of the
+// same kind into one list
+func (r *renderer) blocks(blocks []notionapi.Block) {
+	for i := 0; i < len(blocks); {
+		tag, class := listTag(blocks[i].GetType())
+		if tag == "" {
+			r.block(blocks[i])
+			i++
+			continue
+		}
+		r.printf("<%s%s>\n", tag, classAttr(class))
+		for ; i < len(blocks) && sameList(blocks[i], tag, class); i++ {
+			r.block(blocks[i])
+		}
+		r.printf("</%s>\n", tag)
+	}
+}
+
+func sameList(b notionapi.Block, tag, class string) bool {
+	t, c := listTag(b.GetType())
+	return t == tag && c == class
+}
+
+// listTag returns the list element of list items
+func listTag(t notionapi.BlockType) (tag, class string) {
+	switch t {
+	case notionapi.BlockTypeBulletedListItem:
+		return "ul", ""
+	case notionapi.BlockTypeNumberedListItem:
+		return "ol", ""
+	case notionapi.BlockTypeToDo:
+		return "ul", "notion-to-do-list"
+	}
+	return "", ""
+}
+
+// block renders a single block followed by a newline
+func (r *renderer) block(block notionapi.Block) {
+	switch b := block.(type) {
+	case *notionapi.ParagraphBlock:
+		r.element("p", b.Paragraph.Text)
+		r.indented(b.Paragraph.Children)
+	case *notionapi.Heading1Block:
+		r.heading("h1", b.ID, b.Heading1.Text)
+	case *notionapi.Heading2Block:
+		r.heading("h2", b.ID, b.Heading2.Text)
+	case *notionapi.Heading3Block:
+		r.heading("h3", b.ID, b.Heading3.Text)
+	case *notionapi.BulletedListItemBlock:
+		r.listItem("", b.BulletedListItem.Text, b.BulletedListItem.Children)
+	case *notionapi.NumberedListItemBlock:
+		r.listItem("", b.NumberedListItem.Text, b.NumberedListItem.Children)
+	case *notionapi.ToDoBlock:
+		checkbox := `<input type="checkbox" disabled> `
+		if b.ToDo.Checked {
+			checkbox = `<input type="checkbox" disabled checked> `
+		}
+		r.listItem(checkbox, b.ToDo.Text, b.ToDo.Children)
+	case *notionapi.ToggleBlock:
+		r.printf("<details>\n")
+		r.element("summary", b.Toggle.Text)
+		r.blocks(b.Toggle.Children)
+		r.printf("</details>\n")
+	case *notionapi.QuoteBlock:
+		r.printf("<blockquote>\n")
+		r.element("p", b.Quote.Text)
+		r.blocks(b.Quote.Children)
+		r.printf("</blockquote>\n")
+	case *notionapi.CalloutBlock:
+		r.printf(`<aside class="notion-callout">` + "\n")
+		r.icon(b.Callout.Icon)
+		r.printf("<div>\n")
+		r.element("p", b.Callout.Text)
+		r.blocks(b.Callout.Children)
+		r.printf("</div>\n</aside>\n")
+	case *notionapi.CodeBlock:
+		r.figure(b.Code.Caption, func() {
+			class := ""
+			if b.Code.Language != "" && b.Code.Language != "plain text" {
+				class = "language-" + strings.ReplaceAll(b.Code.Language, " ", "-")
+			}
+			r.printf("<pre><code%s>%s</code></pre>", classAttr(class), escape(b.Code.Text.PlainText()))
+		})
+	case *notionapi.EquationBlock:
+		r.printf(`<div class="notion-equation">%s</div>`+"\n", escape(b.Equation.Expression))
+	case *notionapi.DividerBlock:
+		r.printf("<hr>\n")
+	case *notionapi.ImageBlock:
+		r.figure(b.Image.Caption, func() {
+			r.printf(`<img%s alt="%s">`, srcAttr(r.opts.imageURL(fileURL(b.Image))), escape(b.Image.Caption.PlainText()))
+		})
+	case *notionapi.VideoBlock:
+		r.figure(b.Video.Caption, func() {
+			r.printf(`<video%s controls></video>`, srcAttr(fileURL(b.Video)))
+		})
+	case *notionapi.FileBlock:
+		r.fileLink("notion-file", b.File)
+	case *notionapi.PdfBlock:
+		r.fileLink("notion-pdf", b.Pdf)
+	case *notionapi.BookmarkBlock:
+		r.link("notion-bookmark", b.Bookmark.Caption.PlainText(), b.Bookmark.URL)
+	case *notionapi.EmbedBlock:
+		r.link("notion-embed", "", b.Embed.URL)
+	case *notionapi.LinkPreviewBlock:
+		r.link("notion-link-preview", "", b.LinkPreview.URL)
+	case *notionapi.ChildPageBlock:
+		r.link("notion-page", b.ChildPage.Title, r.opts.pageURL(notionapi.ObjectID(b.ID)))
+	case *notionapi.ChildDatabaseBlock:
+		r.link("notion-database", b.ChildDatabase.Title, r.opts.pageURL(notionapi.ObjectID(b.ID)))
+	case *notionapi.LinkToPageBlock:
+		id := notionapi.ObjectID(b.LinkToPage.PageID)
+		if b.LinkToPage.DatabaseID != "" {
+			id = notionapi.ObjectID(b.LinkToPage.DatabaseID)
+		}
+		u := r.opts.pageURL(id)
+		r.link("notion-page", u, u)
+	case *notionapi.TableOfContentsBlock:
+		r.tableOfContents()
+	case *notionapi.BreadcrumbBlock:
+	default:
+		r.printf("<!-- unsupported block: %s -->\n", escape(string(block.GetType())))
+	}
+}
+
+// element renders text in an element of its own line
+func (r *renderer) element(tag string, text notionapi.Paragraph) {
+	r.printf("<%s>", tag)
+	r.inline(text)
+	r.printf("</%s>\n", tag)
+}
+
+func (r *renderer) heading(tag string, id notionapi.BlockID, text notionapi.Paragraph) {
+	r.printf("<%s%s>", tag, idAttr(id))
+	r.inline(text)
+	r.printf("</%s>\n", tag)
+}
+
+// indented renders the children of a paragraph, which Notion indents
+func (r *renderer) indented(children []notionapi.Block) {
+	if len(children) == 0 {
+		return
+	}
+	r.printf(`<div class="notion-indent">` + "\n")
+	r.blocks(children)
+	r.printf("</div>\n")
+}
+
+func (r *renderer) listItem(prefix string, text notionapi.Paragraph, children []notionapi.Block) {
+	r.printf("<li>%s", prefix)
+	r.inline(text)
+	if len(children) > 0 {
+		r.printf("\n")
+		r.blocks(children)
+	}
+	r.printf("</li>\n")
+}
+
+// figure renders content in a figure, followed by the caption if there is
+// one
+func (r *renderer) figure(caption notionapi.Paragraph, content func()) {
+	r.printf("<figure>")
+	content()
+	if len(caption) > 0 {
+		r.printf("<figcaption>")
+		r.inline(caption)
+		r.printf("</figcaption>")
+	}
+	r.printf("</figure>\n")
+}
+
+func (r *renderer) icon(icon *notionapi.Icon) {
+	if icon == nil {
+		return
+	}
+	switch icon.Type {
+	case notionapi.IconTypeEmoji:
+		r.printf(`<span class="notion-icon">%s</span>`+"\n", escape(icon.Emoji))
+	case notionapi.IconTypeFile, notionapi.IconTypeExternal:
+		f := icon.File
+		if f == nil {
+			f = icon.External
+		}
+		if f != nil {
+			r.printf(`<img class="notion-icon"%s alt="">`+"\n", srcAttr(r.opts.imageURL(f.URL)))
+		}
+	}
+}
+
+// link renders a paragraph holding a link, an empty text shows the URL
+func (r *renderer) link(class, text, u string) {
+	if text == "" {
+		text = u
+	}
+	r.printf(`<p class="%s">`, class)
+	if href := safeURL(u); href != "" {
+		r.printf(`<a href="%s">%s</a>`, escape(href), escape(text))
+	} else {
+		r.printf("%s", escape(text))
+	}
+	r.printf("</p>\n")
+}
+
+// fileLink links to a file, named by its caption or file name
+func (r *renderer) fileLink(class string, f notionapi.BlockFile) {
+	u := fileURL(f)
+	name := f.Caption.PlainText()
+	if name == "" {
+		if parsed, err := url.Parse(u); err == nil && path.Base(parsed.Path) != "/" && path.Base(parsed.Path) != "." {
+			name = path.Base(parsed.Path)
+		}
+	}
+	r.link(class, name, u)
+}
+
+// tableOfContents renders a nested list linking to all headings
+func (r *renderer) tableOfContents() {
+	r.printf(`<nav class="notion-table-of-contents">` + "\n<ul>\n")
+	for _, h := range r.headings {
+		var level int
+		var id notionapi.BlockID
+		var text notionapi.Paragraph
+		switch h := h.(type) {
+		case *notionapi.Heading1Block:
+			level, id, text = 1, h.ID, h.Heading1.Text
+		case *notionapi.Heading2Block:
+			level, id, text = 2, h.ID, h.Heading2.Text
+		case *notionapi.Heading3Block:
+			level, id, text = 3, h.ID, h.Heading3.Text
+		}
+		r.printf(`<li class="notion-toc-%d"><a href="#%s">%s</a></li>`+"\n", level, escape(anchor(id)), escape(text.PlainText()))
+	}
+	r.printf("</ul>\n</nav>\n")
+}
+
+// headings returns the headings of blocks and their children in order
+func headings(blocks []notionapi.Block) []notionapi.Block {
+	var result []notionapi.Block
+	for _, b := range blocks {
+		switch b.GetType() {
+		case notionapi.BlockTypeHeading1, notionapi.BlockTypeHeading2, notionapi.BlockTypeHeading3:
+			result = append(result, b)
+		}
+		result = append(result, headings(children(b))...)
+	}
+	return result
+}
+
+func children(b notionapi.Block) []notionapi.Block {
+	if _, _, children := notionapi.BlockChildren(b); children != nil {
+		return *children
+	}
+	return nil
+}
+
+func fileURL(f notionapi.BlockFile) string {
+	if f.File != nil {
+		return f.File.URL
+	}
+	if f.External != nil {
+		return f.External.URL
+	}
+	return ""
+}
+
+// anchor returns the id attribute of a heading
+func anchor(id notionapi.BlockID) string {
+	return strings.ReplaceAll(id.String(), "-", "")
+}
+
+func idAttr(id notionapi.BlockID) string {
+	if id == "" {
+		return ""
+	}
+	return ` id="` + escape(anchor(id)) + `"`
+}
+
+func classAttr(class string) string {
+	if class == "" {
+		return ""
+	}
+	return ` class="` + escape(class) + `"`
+}
+
+func srcAttr(u string) string {
+	if u = safeURL(u); u == "" {
+		return ""
+	}
+	return ` src="` + escape(u) + `"`
+}
+
+var escaper = strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;", `"`, "&#34;", `'`, "&#39;")
+
+// escape escapes text and attribute values
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// safeURL returns u if it is relative or uses a scheme which can not run
+// scripts, and "" otherwise
+func safeURL(u string) string {
+	parsed, err := url.Parse(strings.TrimSpace(u))
+	if err != nil {
+		return ""
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https", "mailto", "tel":
+		return u
+	}
+	return ""
+}
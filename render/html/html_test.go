@@ -0,0 +1,155 @@
+package html_test
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/jomei/notionapi/render/html"
+	"github.com/jomei/notionapi/rt"
+)
+
+var update = flag.Bool("update", false, "update the rendered pages in testdata")
+
+type RoundTripFunc func(req *http.Request) *http.Response
+
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func TestRender(t *testing.T) {
+	files := map[string]string{
+		"/v1/blocks/page_id/children":   "testdata/children_root.json",
+		"/v1/blocks/bullet_id/children": "testdata/children_bullet.json",
+		"/v1/blocks/toggle_id/children": "testdata/children_toggle.json",
+	}
+	client := notionapi.NewClient("some_token", notionapi.WithHTTPClient(&http.Client{
+		Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+			b, err := os.Open(files[req.URL.Path])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: b, Header: make(http.Header)}
+		}),
+	}))
+	blocks, err := client.Block.GetTree(context.Background(), "page_id", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &html.Options{
+		PageURL: func(id notionapi.ObjectID) string {
+			return "/wiki/" + id.String()
+		},
+		ImageURL: func(u string) string {
+			return "/assets/" + u[strings.LastIndex(u, "/")+1:strings.Index(u, "?")]
+		},
+	}
+
+	got := html.Render(blocks, opts)
+	golden := "testdata/page.html"
+	if *update {
+		if err := ioutil.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Render() differs from %s, run go test -update\n%s", golden, got)
+	}
+	if again := html.Render(blocks, opts); string(again) != string(got) {
+		t.Errorf("Render() is not deterministic")
+	}
+}
+
+func TestRenderRichText(t *testing.T) {
+	tests := []struct {
+		name string
+		text notionapi.Paragraph
+		want string
+	}{
+		{
+			name: "escapes text",
+			text: notionapi.Paragraph{rt.Text(`<b>"a" & 'b'</b>`)},
+			want: "&lt;b&gt;&#34;a&#34; &amp; &#39;b&#39;&lt;/b&gt;",
+		},
+		{
+			name: "maps colors to classes",
+			text: notionapi.Paragraph{rt.Color(notionapi.ColorBlue, "a"), rt.Color(notionapi.ColorGrayBackground, "b")},
+			want: `<span class="notion-blue">a</span><span class="notion-gray-background">b</span>`,
+		},
+		{
+			name: "links to pages with the default URL",
+			text: notionapi.Paragraph{rt.MentionPage("1234-abcd")},
+			want: `<a class="notion-mention" href="https://www.notion.so/1234abcd">Untitled</a>`,
+		},
+		{
+			name: "drops links running scripts",
+			text: notionapi.Paragraph{rt.Link("a", " JavaScript:alert(1)"), rt.Link("b", "data:text/html,x")},
+			want: "ab",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(html.RenderRichText(tt.text, nil)); got != tt.want {
+				t.Errorf("RenderRichText() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderRichText_PageLinks(t *testing.T) {
+	opts := &html.Options{PageURL: func(id notionapi.ObjectID) string { return "/wiki/" + id.String() }}
+	tests := []struct {
+		name string
+		text notionapi.RichText
+		want string
+	}{
+		{
+			name: "relative link",
+			text: rt.Link("a", "/0123456789abcdef0123456789abcdef"),
+			want: `<a href="/wiki/01234567-89ab-cdef-0123-456789abcdef">a</a>`,
+		},
+		{
+			name: "notion.so link with title",
+			text: rt.Link("a", "https://www.notion.so/Deploy-runbook-0123456789abcdef0123456789abcdef#section"),
+			want: `<a href="/wiki/01234567-89ab-cdef-0123-456789abcdef">a</a>`,
+		},
+		{
+			name: "href with dashed ID",
+			text: notionapi.RichText{
+				Type:      notionapi.ObjectTypeText,
+				Text:      notionapi.Text{Content: "a"},
+				Href:      "https://notion.so/01234567-89ab-cdef-0123-456789abcdef",
+				PlainText: "a",
+			},
+			want: `<a href="/wiki/01234567-89ab-cdef-0123-456789abcdef">a</a>`,
+		},
+		{
+			name: "other links",
+			text: rt.Link("a", "https://example.com/0123456789abcdef0123456789abcdef"),
+			want: `<a href="https://example.com/0123456789abcdef0123456789abcdef">a</a>`,
+		},
+		{
+			name: "relative links to other paths",
+			text: rt.Link("a", "/docs/intro"),
+			want: `<a href="/docs/intro">a</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(html.RenderRichText(notionapi.Paragraph{tt.text}, opts)); got != tt.want {
+				t.Errorf("RenderRichText() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
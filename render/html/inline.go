@@ -0,0 +1,173 @@
+package html
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// inline renders rich text. Newlines become line breaks.
+func (r *renderer) inline(p notionapi.Paragraph) {
+	for _, rt := range p {
+		r.b.WriteString(r.span(rt))
+	}
+}
+
+// span renders a rich text object wrapped in the elements of its
+// annotations, from the innermost code to the outermost link
+func (r *renderer) span(rt notionapi.RichText) string {
+	var a notionapi.Annotations
+	if rt.Annotations != nil {
+		a = *rt.Annotations
+	}
+
+	var s, href string
+	switch rt.Type {
+	case notionapi.ObjectTypeMention:
+		s = r.mention(rt)
+	case notionapi.ObjectTypeEquation:
+		if rt.Equation != nil {
+			s = `<span class="notion-equation">` + escape(rt.Equation.Expression) + `</span>`
+		}
+	default:
+		text := rt.Text.Content
+		if text == "" {
+			text = rt.PlainText
+		}
+		s = strings.ReplaceAll(escape(text), "\n", "<br>")
+		href = rt.Href
+		if rt.Text.Link != nil {
+			href = rt.Text.Link.URL
+		}
+		if id, ok := linkedPage(href); ok {
+			href = r.opts.pageURL(id)
+		}
+	}
+	if s == "" {
+		return ""
+	}
+
+	if a.Code {
+		s = "<code>" + s + "</code>"
+	}
+	if a.Italic {
+		s = "<em>" + s + "</em>"
+	}
+	if a.Bold {
+		s = "<strong>" + s + "</strong>"
+	}
+	if a.Strikethrough {
+		s = "<s>" + s + "</s>"
+	}
+	if a.Underline {
+		s = "<u>" + s + "</u>"
+	}
+	if class := colorClass(a.Color); class != "" {
+		s = `<span class="` + class + `">` + s + "</span>"
+	}
+	if href = safeURL(href); href != "" {
+		s = `<a href="` + escape(href) + `">` + s + "</a>"
+	}
+	return s
+}
+
+func (r *renderer) mention(rt notionapi.RichText) string {
+	m := rt.Mention
+	if m == nil {
+		return escape(rt.PlainText)
+	}
+	switch m.Type {
+	case notionapi.MentionTypePage:
+		if m.Page != nil {
+			return mentionLink(titleOf(rt), r.opts.pageURL(notionapi.ObjectID(m.Page.ID)))
+		}
+	case notionapi.MentionTypeDatabase:
+		if m.Database != nil {
+			return mentionLink(titleOf(rt), r.opts.pageURL(notionapi.ObjectID(m.Database.ID)))
+		}
+	case notionapi.MentionTypeLinkPreview:
+		if m.LinkPreview != nil {
+			return mentionLink(m.LinkPreview.URL, m.LinkPreview.URL)
+		}
+	case notionapi.MentionTypeUser:
+		text := rt.PlainText
+		if text == "" && m.User != nil {
+			text = "@" + m.User.Name
+			if m.User.Name == "" {
+				text = "@" + m.User.ID.String()
+			}
+		}
+		return `<span class="notion-mention">` + escape(text) + `</span>`
+	case notionapi.MentionTypeDate:
+		if m.Date != nil && m.Date.Start != nil {
+			start := dateStart(m.Date)
+			text := rt.PlainText
+			if text == "" {
+				text = start
+			}
+			return `<time datetime="` + escape(start) + `">` + escape(text) + `</time>`
+		}
+	}
+	return escape(rt.PlainText)
+}
+
+func mentionLink(text, u string) string {
+	if u = safeURL(u); u == "" {
+		return `<span class="notion-mention">` + escape(text) + `</span>`
+	}
+	return `<a class="notion-mention" href="` + escape(u) + `">` + escape(text) + `</a>`
+}
+
+func titleOf(rt notionapi.RichText) string {
+	if rt.PlainText == "" {
+		return "Untitled"
+	}
+	return rt.PlainText
+}
+
+// dateStart formats the start of d as the API does, keeping date-only
+// values without a time
+func dateStart(d *notionapi.DateObject) string {
+	if d.DateOnly {
+		return d.StartTime(nil).Format("2006-01-02")
+	}
+	return d.StartTime(nil).Format("2006-01-02T15:04:05Z07:00")
+}
+
+// colorClass returns the class of a color, e.g. "notion-red-background"
+// for red_background. The default color has no class.
+func colorClass(c notionapi.Color) string {
+	if c == "" || c == notionapi.ColorDefault {
+		return ""
+	}
+	return "notion-" + strings.ReplaceAll(string(c), "_", "-")
+}
+
+// pageIDSuffix matches the ID at the end of the path of a page URL, with or
+// without dashes
+var pageIDSuffix = regexp.MustCompile(`(?:^|[/-])([0-9a-f]{32}|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+
+// linkedPage returns the ID of the page a link in text points to. Notion
+// links to pages with relative URLs like /<id> or with notion.so URLs ending
+// in the ID.
+func linkedPage(href string) (notionapi.ObjectID, bool) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+	host := strings.ToLower(u.Host)
+	switch {
+	case u.Scheme == "" && host == "" && strings.HasPrefix(u.Path, "/") && strings.Count(u.Path, "/") == 1:
+	case (u.Scheme == "http" || u.Scheme == "https") && (host == "notion.so" || strings.HasSuffix(host, ".notion.so")):
+	default:
+		return "", false
+	}
+	m := pageIDSuffix.FindStringSubmatch(strings.ToLower(u.Path))
+	if m == nil {
+		return "", false
+	}
+	id := strings.ReplaceAll(m[1], "-", "")
+	return notionapi.ObjectID(id[:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:]), true
+}
@@ -0,0 +1,178 @@
+package markdown
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatter renders the page properties as a YAML document between "---"
+// lines. Keys are sorted, properties without a plain value are skipped.
+func frontMatter(props notionapi.Properties) ([]byte, error) {
+	values := make(map[string]interface{}, len(props))
+	for name, p := range props {
+		if v, ok := propertyValue(p); ok {
+			values[name] = v
+		}
+	}
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		data = nil
+	}
+	return append(append([]byte("---\n"), data...), "---\n"...), nil
+}
+
+// propertyValue returns the value of p as plain strings, numbers and lists
+func propertyValue(p notionapi.Property) (interface{}, bool) {
+	if rv := reflect.ValueOf(p); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, false
+		}
+		p = rv.Elem().Interface().(notionapi.Property)
+	}
+
+	switch p := p.(type) {
+	case notionapi.TitleProperty:
+		return p.Title.PlainText(), true
+	case notionapi.RichTextProperty:
+		return p.RichText.PlainText(), true
+	case notionapi.NumberProperty:
+		if p.Number == nil {
+			return nil, true
+		}
+		return *p.Number, true
+	case notionapi.SelectProperty:
+		if p.Select == nil {
+			return nil, true
+		}
+		return p.Select.Name, true
+	case notionapi.MultiSelectProperty:
+		names := make([]string, len(p.MultiSelect))
+		for i, o := range p.MultiSelect {
+			names[i] = o.Name
+		}
+		return names, true
+	case notionapi.DateProperty:
+		return dateValue(p.Date), true
+	case notionapi.PeopleProperty:
+		names := make([]string, len(p.People))
+		for i, u := range p.People {
+			names[i] = userName(u)
+		}
+		return names, true
+	case notionapi.FilesProperty:
+		return nonNil(p.URLs()), true
+	case notionapi.CheckboxProperty:
+		return p.Checkbox, true
+	case notionapi.URLProperty:
+		return stringOrNil(p.URL), true
+	case notionapi.EmailProperty:
+		return stringOrNil(p.Email), true
+	case notionapi.PhoneNumberProperty:
+		return stringOrNil(p.PhoneNumber), true
+	case notionapi.FormulaProperty:
+		f := p.Formula
+		if s, ok := f.AsString(); ok {
+			return s, true
+		}
+		if n, ok := f.AsNumber(); ok {
+			return n, true
+		}
+		if b, ok := f.AsBool(); ok {
+			return b, true
+		}
+		if d, ok := f.AsDate(); ok {
+			return dateValue(&d), true
+		}
+		return nil, true
+	case notionapi.RelationProperty:
+		ids := make([]string, len(p.Relation))
+		for i, r := range p.Relation {
+			ids[i] = r.ID.String()
+		}
+		return ids, true
+	case notionapi.RollupProperty:
+		r := p.Rollup
+		if n, ok := r.AsNumber(); ok {
+			return n, true
+		}
+		if d, ok := r.AsDate(); ok {
+			return dateValue(&d), true
+		}
+		if r.Type == notionapi.RollupTypeArray {
+			values := make([]interface{}, 0, len(r.Array))
+			for _, item := range r.AsArray() {
+				if v, ok := propertyValue(item); ok {
+					values = append(values, v)
+				}
+			}
+			return values, true
+		}
+		return nil, true
+	case notionapi.CreatedTimeProperty:
+		return p.CreatedTime.Format(time.RFC3339), true
+	case notionapi.LastEditedTimeProperty:
+		return p.LastEditedTime.Format(time.RFC3339), true
+	case notionapi.CreatedByProperty:
+		return userName(p.CreatedBy), true
+	case notionapi.LastEditedByProperty:
+		return userName(p.LastEditedBy), true
+	}
+	return nil, false
+}
+
+// dateValue returns the start of d, or a map with start and end for ranges
+func dateValue(d *notionapi.DateObject) interface{} {
+	if d == nil || d.Start == nil {
+		return nil
+	}
+	start, end := dateText(d)
+	if end == "" {
+		return start
+	}
+	return map[string]string{"start": start, "end": end}
+}
+
+// dateText formats the start and end of d as the API does, keeping
+// date-only values without a time
+func dateText(d *notionapi.DateObject) (start, end string) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return "", ""
+	}
+	var text struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}
+	if err := json.Unmarshal(data, &text); err != nil {
+		return "", ""
+	}
+	return text.Start, text.End
+}
+
+func userName(u notionapi.User) string {
+	if u.Name != "" {
+		return u.Name
+	}
+	return u.ID.String()
+}
+
+func stringOrNil(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,225 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/jomei/notionapi"
+)
+
+// inline renders rich text as inline Markdown. Newlines become hard line
+// breaks.
+func (r *renderer) inline(p notionapi.Paragraph) string {
+	var b strings.Builder
+	spans := merge(p)
+	for i := 0; i < len(spans); {
+		// neighbouring spans with the same link share one link
+		u := ""
+		if isText(spans[i]) {
+			u = href(spans[i])
+		}
+		var text strings.Builder
+		j := i
+		for ; j < len(spans) && (j == i || (u != "" && isText(spans[j]) && href(spans[j]) == u)); j++ {
+			text.WriteString(r.span(spans[j]))
+		}
+		b.WriteString(wrapLink(text.String(), u))
+		i = j
+	}
+	return escapeLineStarts(b.String())
+}
+
+// merge joins neighbouring text spans with the same annotations and link so
+// that their delimiters are not rendered back to back
+func merge(p notionapi.Paragraph) notionapi.Paragraph {
+	merged := make(notionapi.Paragraph, 0, len(p))
+	for _, rt := range p {
+		if n := len(merged); n > 0 && isText(merged[n-1]) && isText(rt) &&
+			annotations(merged[n-1]) == annotations(rt) && href(merged[n-1]) == href(rt) {
+			last := &merged[n-1]
+			last.Text = notionapi.Text{Content: content(*last) + content(rt), Link: last.Text.Link}
+			last.PlainText = ""
+			continue
+		}
+		merged = append(merged, rt)
+	}
+	return merged
+}
+
+func isText(rt notionapi.RichText) bool {
+	return rt.Type == "" || rt.Type == notionapi.ObjectTypeText
+}
+
+func annotations(rt notionapi.RichText) notionapi.Annotations {
+	if rt.Annotations == nil {
+		return notionapi.Annotations{}
+	}
+	a := *rt.Annotations
+	a.Color = ""
+	return a
+}
+
+func href(rt notionapi.RichText) string {
+	if rt.Text.Link != nil {
+		return rt.Text.Link.URL
+	}
+	return rt.Href
+}
+
+func content(rt notionapi.RichText) string {
+	if rt.Text.Content != "" {
+		return rt.Text.Content
+	}
+	return rt.PlainText
+}
+
+// span renders a single rich text object with its annotations. Links of
+// text are added by inline.
+func (r *renderer) span(rt notionapi.RichText) string {
+	a := annotations(rt)
+	switch rt.Type {
+	case notionapi.ObjectTypeMention:
+		return emphasize(r.mention(rt), a)
+	case notionapi.ObjectTypeEquation:
+		if rt.Equation == nil {
+			return ""
+		}
+		return emphasize("$"+rt.Equation.Expression+"$", a)
+	}
+
+	text := content(rt)
+	if a.Code {
+		return emphasize(codeSpan(text), a)
+	}
+	lead, core, trail := splitSpace(text)
+	if core == "" {
+		return breaks(escape(text))
+	}
+	return breaks(escape(lead)) + emphasize(breaks(escape(core)), a) + breaks(escape(trail))
+}
+
+func (r *renderer) mention(rt notionapi.RichText) string {
+	m := rt.Mention
+	if m == nil {
+		return escape(rt.PlainText)
+	}
+	switch m.Type {
+	case notionapi.MentionTypePage:
+		if m.Page != nil {
+			return link(titleOf(rt), r.opts.pageURL(notionapi.ObjectID(m.Page.ID)))
+		}
+	case notionapi.MentionTypeDatabase:
+		if m.Database != nil {
+			return link(titleOf(rt), r.opts.pageURL(notionapi.ObjectID(m.Database.ID)))
+		}
+	case notionapi.MentionTypeLinkPreview:
+		if m.LinkPreview != nil {
+			return link("", m.LinkPreview.URL)
+		}
+	case notionapi.MentionTypeUser:
+		if rt.PlainText == "" && m.User != nil {
+			name := m.User.Name
+			if name == "" {
+				name = m.User.ID.String()
+			}
+			return escape("@" + name)
+		}
+	case notionapi.MentionTypeDate:
+		if rt.PlainText == "" && m.Date != nil {
+			start, end := dateText(m.Date)
+			if end != "" {
+				start += " → " + end
+			}
+			return escape(start)
+		}
+	}
+	return escape(rt.PlainText)
+}
+
+func titleOf(rt notionapi.RichText) string {
+	if rt.PlainText == "" {
+		return "Untitled"
+	}
+	return rt.PlainText
+}
+
+// emphasize wraps s in the delimiters of the annotations. Colors have no
+// Markdown equivalent and are dropped.
+func emphasize(s string, a notionapi.Annotations) string {
+	if s == "" {
+		return s
+	}
+	if a.Italic {
+		s = "*" + s + "*"
+	}
+	if a.Bold {
+		s = "**" + s + "**"
+	}
+	if a.Strikethrough {
+		s = "~~" + s + "~~"
+	}
+	if a.Underline {
+		s = "<u>" + s + "</u>"
+	}
+	return s
+}
+
+func wrapLink(s, u string) string {
+	if u == "" {
+		return s
+	}
+	return "[" + s + "](" + destination(u) + ")"
+}
+
+// splitSpace splits the leading and trailing white space off s, since
+// delimiters next to white space do not count as emphasis
+func splitSpace(s string) (lead, core, trail string) {
+	core = strings.TrimLeftFunc(s, unicode.IsSpace)
+	lead = s[:len(s)-len(core)]
+	core = strings.TrimRightFunc(core, unicode.IsSpace)
+	trail = s[len(lead)+len(core):]
+	return lead, core, trail
+}
+
+// codeSpan fences s with more backticks than it contains in a row
+func codeSpan(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	fence := strings.Repeat("`", longestRun(s, '`')+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
+func breaks(s string) string {
+	return strings.ReplaceAll(s, "\n", "\\\n")
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `~`, `\~`, `#`, `\#`, `&`, `\&`, `$`, `\$`,
+)
+
+// escape escapes the characters starting inline Markdown
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// escapeLineStarts escapes the characters which would turn a line into a
+// list item or a setext heading underline
+func escapeLineStarts(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		switch {
+		case line == "":
+		case line[0] == '-' || line[0] == '+' || line[0] == '=':
+			lines[i] = `\` + line
+		case line[0] >= '0' && line[0] <= '9':
+			j := strings.IndexFunc(line, func(r rune) bool { return r < '0' || r > '9' })
+			if j > 0 && (line[j] == '.' || line[j] == ')') {
+				lines[i] = line[:j] + `\` + line[j:]
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
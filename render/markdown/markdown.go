@@ -0,0 +1,315 @@
+// Package markdown converts between Notion blocks and CommonMark with the
+// GitHub flavored extensions for task lists and strikethrough:
+//
+//	page, err := client.Page.Get(ctx, pageID)
+//	if err != nil {
+//		return err
+//	}
+//	md, err := markdown.RenderPage(ctx, client.Block, page, &markdown.Options{FrontMatter: true})
+//
+// Toggles become <details> elements, callouts become quotes starting with
+// their emoji and images, videos and files become links. Blocks without a
+// Markdown equivalent are rendered as HTML comments.
+//
+// ToBlocks goes the other way and parses Markdown into blocks to create.
+package markdown
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// Options configures the rendering
+type Options struct {
+	// FrontMatter adds the page properties as YAML front matter
+	FrontMatter bool
+	// PageURL returns the link target of child pages, links to pages and
+	// page mentions. The default links to notion.so.
+	PageURL func(id notionapi.ObjectID) string
+}
+
+func (o *Options) pageURL(id notionapi.ObjectID) string {
+	if o != nil && o.PageURL != nil {
+		return o.PageURL(id)
+	}
+	return "https://www.notion.so/" + strings.ReplaceAll(id.String(), "-", "")
+}
+
+// Fetch returns the children of the block id with their children attached,
+// see BlockService.GetTree
+func Fetch(ctx context.Context, blocks notionapi.BlockService, id notionapi.BlockID) ([]notionapi.Block, error) {
+	return blocks.GetTree(ctx, id, nil)
+}
+
+// RenderPage fetches the blocks of page and renders them, preceded by the
+// page properties if opts.FrontMatter is set
+func RenderPage(ctx context.Context, blocks notionapi.BlockService, page *notionapi.Page, opts *Options) ([]byte, error) {
+	children, err := Fetch(ctx, blocks, notionapi.BlockID(page.ID))
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	if opts != nil && opts.FrontMatter {
+		fm, err := frontMatter(page.Properties)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(fm)
+		b.WriteString("\n")
+	}
+	b.Write(Render(children, opts))
+	return []byte(b.String()), nil
+}
+
+// Render renders blocks whose children are already fetched, e.g. by Fetch
+// or BlockService.GetTree
+func Render(blocks []notionapi.Block, opts *Options) []byte {
+	s := (&renderer{opts: opts}).blocks(blocks)
+	if s == "" {
+		return nil
+	}
+	return []byte(s + "\n")
+}
+
+type renderer struct {
+	opts *Options
+}
+
+// blocks renders a list of sibling blocks. List items of the same kind are
+// kept together, all other blocks are separated by an empty line.
+func (r *renderer) blocks(blocks []notionapi.Block) string {
+	var b strings.Builder
+	var prev notionapi.BlockType
+	number := 0
+	for _, block := range blocks {
+		if block.GetType() == notionapi.BlockTypeNumberedListItem {
+			number++
+		} else {
+			number = 0
+		}
+		s := r.block(block, number)
+		if s == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			if isListItem(block.GetType()) && block.GetType() == prev {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+		b.WriteString(s)
+		prev = block.GetType()
+	}
+	return b.String()
+}
+
+func isListItem(t notionapi.BlockType) bool {
+	switch t {
+	case notionapi.BlockTypeBulletedListItem, notionapi.BlockTypeNumberedListItem, notionapi.BlockTypeToDo:
+		return true
+	}
+	return false
+}
+
+// block renders a single block without a trailing newline. number is the
+// position of a numbered list item in its list.
+func (r *renderer) block(block notionapi.Block, number int) string {
+	switch b := block.(type) {
+	case *notionapi.ParagraphBlock:
+		return join(r.inline(b.Paragraph.Text), r.blocks(b.Paragraph.Children))
+	case *notionapi.Heading1Block:
+		return "# " + r.heading(b.Heading1.Text)
+	case *notionapi.Heading2Block:
+		return "## " + r.heading(b.Heading2.Text)
+	case *notionapi.Heading3Block:
+		return "### " + r.heading(b.Heading3.Text)
+	case *notionapi.BulletedListItemBlock:
+		return r.listItem("- ", b.BulletedListItem.Text, b.BulletedListItem.Children)
+	case *notionapi.NumberedListItemBlock:
+		return r.listItem(strconv.Itoa(number)+". ", b.NumberedListItem.Text, b.NumberedListItem.Children)
+	case *notionapi.ToDoBlock:
+		marker := "- [ ] "
+		if b.ToDo.Checked {
+			marker = "- [x] "
+		}
+		return r.listItem(marker, b.ToDo.Text, b.ToDo.Children)
+	case *notionapi.ToggleBlock:
+		summary := "<details>\n<summary>" + html.EscapeString(b.Toggle.Text.PlainText()) + "</summary>"
+		if children := r.blocks(b.Toggle.Children); children != "" {
+			return summary + "\n\n" + children + "\n\n</details>"
+		}
+		return summary + "\n</details>"
+	case *notionapi.QuoteBlock:
+		return quote(join(r.inline(b.Quote.Text), r.blocks(b.Quote.Children)))
+	case *notionapi.CalloutBlock:
+		text := r.inline(b.Callout.Text)
+		if icon := b.Callout.Icon; icon != nil && icon.Emoji != "" {
+			text = strings.TrimSpace(icon.Emoji + " " + text)
+		}
+		return quote(join(text, r.blocks(b.Callout.Children)))
+	case *notionapi.CodeBlock:
+		return codeFence(b.Code.Text.PlainText(), b.Code.Language)
+	case *notionapi.EquationBlock:
+		return "$$\n" + b.Equation.Expression + "\n$$"
+	case *notionapi.DividerBlock:
+		return "---"
+	case *notionapi.ImageBlock:
+		return "!" + link(b.Image.Caption.PlainText(), fileURL(b.Image))
+	case *notionapi.VideoBlock:
+		return fileLink(b.Video)
+	case *notionapi.FileBlock:
+		return fileLink(b.File)
+	case *notionapi.PdfBlock:
+		return fileLink(b.Pdf)
+	case *notionapi.BookmarkBlock:
+		return link(b.Bookmark.Caption.PlainText(), b.Bookmark.URL)
+	case *notionapi.EmbedBlock:
+		return link("", b.Embed.URL)
+	case *notionapi.LinkPreviewBlock:
+		return link("", b.LinkPreview.URL)
+	case *notionapi.ChildPageBlock:
+		return link(b.ChildPage.Title, r.opts.pageURL(notionapi.ObjectID(b.ID)))
+	case *notionapi.ChildDatabaseBlock:
+		return link(b.ChildDatabase.Title, r.opts.pageURL(notionapi.ObjectID(b.ID)))
+	case *notionapi.LinkToPageBlock:
+		id := notionapi.ObjectID(b.LinkToPage.PageID)
+		if b.LinkToPage.DatabaseID != "" {
+			id = notionapi.ObjectID(b.LinkToPage.DatabaseID)
+		}
+		return link("", r.opts.pageURL(id))
+	case *notionapi.TableOfContentsBlock, *notionapi.BreadcrumbBlock:
+		return ""
+	}
+	return fmt.Sprintf("<!-- unsupported block: %s -->", block.GetType())
+}
+
+func (r *renderer) heading(text notionapi.Paragraph) string {
+	return strings.ReplaceAll(r.inline(text), "\\\n", " ")
+}
+
+// listItem renders the text after marker and indents the continuation lines
+// and children to the content of the item. Nested lists follow the text
+// directly to keep the list tight.
+func (r *renderer) listItem(marker string, text notionapi.Paragraph, children []notionapi.Block) string {
+	s, nested := r.inline(text), r.blocks(children)
+	content := join(s, nested)
+	if s != "" && nested != "" && isListItem(children[0].GetType()) {
+		content = s + "\n" + nested
+	}
+	if content == "" {
+		return strings.TrimRight(marker, " ")
+	}
+	return marker + indent(content, len(marker))
+}
+
+// join separates text and the rendered children by an empty line, skipping
+// empty parts
+func join(text, children string) string {
+	switch {
+	case text == "":
+		return children
+	case children == "":
+		return text
+	}
+	return text + "\n\n" + children
+}
+
+// indent indents all but the first line of s by n spaces, leaving empty lines
+// empty
+func indent(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	prefix := strings.Repeat(" ", n)
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = prefix + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func quote(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// codeFence fences code with more backticks than it contains in a row
+func codeFence(code, language string) string {
+	fence := strings.Repeat("`", max(3, longestRun(code, '`')+1))
+	if language == "plain text" {
+		language = ""
+	}
+	language = strings.ReplaceAll(language, " ", "-")
+	return fence + language + "\n" + code + "\n" + fence
+}
+
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != c {
+			run = 0
+			continue
+		}
+		run++
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func fileURL(f notionapi.BlockFile) string {
+	if f.File != nil {
+		return f.File.URL
+	}
+	if f.External != nil {
+		return f.External.URL
+	}
+	return ""
+}
+
+// fileLink links to the file, named by its caption or file name
+func fileLink(f notionapi.BlockFile) string {
+	u := fileURL(f)
+	name := f.Caption.PlainText()
+	if name == "" {
+		if parsed, err := url.Parse(u); err == nil && path.Base(parsed.Path) != "/" && path.Base(parsed.Path) != "." {
+			name = path.Base(parsed.Path)
+		}
+	}
+	return link(name, u)
+}
+
+// link renders an inline link. An empty text shows the URL.
+func link(text, u string) string {
+	if text == "" {
+		text = u
+	}
+	return "[" + escape(text) + "](" + destination(u) + ")"
+}
+
+// destination encodes the characters which end a link destination
+func destination(u string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E").Replace(u)
+}
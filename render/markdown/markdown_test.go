@@ -0,0 +1,176 @@
+package markdown_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/jomei/notionapi/render/markdown"
+	"github.com/jomei/notionapi/rt"
+)
+
+var update = flag.Bool("update", false, "update the rendered pages in testdata")
+
+type RoundTripFunc func(req *http.Request) *http.Response
+
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+// newClient returns a client answering block children requests with the
+// files for the path and cursor of the request
+func newClient(t *testing.T, files map[string]string) *notionapi.Client {
+	return notionapi.NewClient("some_token", notionapi.WithHTTPClient(&http.Client{
+		Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+			key := req.URL.Path
+			if cursor := req.URL.Query().Get("start_cursor"); cursor != "" {
+				key += "?" + cursor
+			}
+			statusCode := http.StatusOK
+			file, ok := files[key]
+			if !ok {
+				statusCode = http.StatusNotFound
+				file = "../../testdata/validation_error.json"
+			}
+			b, err := os.Open(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return &http.Response{StatusCode: statusCode, Body: b, Header: make(http.Header)}
+		}),
+	}))
+}
+
+var pageFiles = map[string]string{
+	"/v1/blocks/page_id/children":          "testdata/children_page_1.json",
+	"/v1/blocks/page_id/children?cursor_2": "testdata/children_page_2.json",
+	"/v1/blocks/bullet_id/children":        "testdata/children_bullet.json",
+	"/v1/blocks/nested_id/children":        "testdata/children_nested.json",
+	"/v1/blocks/toggle_id/children":        "testdata/children_toggle.json",
+}
+
+func TestRenderPage(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/page.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var page notionapi.Page
+	if err := json.Unmarshal(data, &page); err != nil {
+		t.Fatal(err)
+	}
+	opts := &markdown.Options{
+		FrontMatter: true,
+		PageURL: func(id notionapi.ObjectID) string {
+			return "/docs/" + id.String()
+		},
+	}
+
+	got, err := markdown.RenderPage(context.Background(), newClient(t, pageFiles).Block, &page, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := "testdata/page.md"
+	if *update {
+		if err := ioutil.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("RenderPage() differs from %s, run go test -update\n%s", golden, got)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	t.Run("returns errors of nested requests", func(t *testing.T) {
+		files := map[string]string{
+			"/v1/blocks/page_id/children":          "testdata/children_page_1.json",
+			"/v1/blocks/page_id/children?cursor_2": "testdata/children_page_2.json",
+		}
+		_, err := markdown.Fetch(context.Background(), newClient(t, files).Block, "page_id")
+		var apiErr *notionapi.Error
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Fetch() error = %v, want *notionapi.Error", err)
+		}
+	})
+}
+
+func TestRender(t *testing.T) {
+	paragraph := func(text ...notionapi.RichText) notionapi.Block {
+		b := &notionapi.ParagraphBlock{Type: notionapi.BlockTypeParagraph}
+		b.Paragraph.Text = text
+		return b
+	}
+	bullet := func(text string, children ...notionapi.Block) notionapi.Block {
+		b := &notionapi.BulletedListItemBlock{Type: notionapi.BlockTypeBulletedListItem}
+		b.BulletedListItem.Text = notionapi.Paragraph{rt.Text(text)}
+		b.BulletedListItem.Children = children
+		return b
+	}
+	numbered := func(text string, children ...notionapi.Block) notionapi.Block {
+		b := &notionapi.NumberedListItemBlock{Type: notionapi.BlockTypeNumberedListItem}
+		b.NumberedListItem.Text = notionapi.Paragraph{rt.Text(text)}
+		b.NumberedListItem.Children = children
+		return b
+	}
+
+	tests := []struct {
+		name   string
+		blocks []notionapi.Block
+		want   string
+	}{
+		{
+			name:   "merges spans with the same annotations",
+			blocks: []notionapi.Block{paragraph(rt.Bold("a"), rt.Bold("b"), rt.Text(" "), rt.Italic(" c "))},
+			want:   "**ab**  *c* \n",
+		},
+		{
+			name:   "fences code spans containing backticks",
+			blocks: []notionapi.Block{paragraph(rt.Code("a`b"), rt.Code("`x"))},
+			want:   "``a`b`x``\n",
+		},
+		{
+			name:   "links page mentions to notion.so",
+			blocks: []notionapi.Block{paragraph(rt.MentionPage("1234-abcd"))},
+			want:   "[Untitled](https://www.notion.so/1234abcd)\n",
+		},
+		{
+			name:   "escapes list markers at the start of a paragraph",
+			blocks: []notionapi.Block{paragraph(rt.Text("- not\n+ a\n10) list"))},
+			want:   "\\- not\\\n\\+ a\\\n10\\) list\n",
+		},
+		{
+			name: "indents nested lists to the content of the item",
+			blocks: []notionapi.Block{
+				numbered("one"),
+				numbered("two", bullet("a", bullet("b")), paragraph(rt.Text("more"))),
+				paragraph(rt.Text("after")),
+				numbered("restart"),
+			},
+			want: "1. one\n2. two\n   - a\n     - b\n\n   more\n\nafter\n\n1. restart\n",
+		},
+		{
+			name:   "skips empty paragraphs",
+			blocks: []notionapi.Block{paragraph(), paragraph(rt.Text("text"))},
+			want:   "text\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(markdown.Render(tt.blocks, nil))
+			if got != tt.want {
+				t.Errorf("Render() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,380 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jomei/notionapi"
+	"github.com/jomei/notionapi/rt"
+)
+
+// ToBlocks parses GitHub flavored Markdown into blocks. Lists and quotes are
+// nested as deep as in src and long documents have more blocks than one
+// request takes, so the blocks are sent with BlockService.AppendAll, which
+// splits them into requests within the limits of the API:
+//
+//	blocks, err := markdown.ToBlocks(src)
+//	if err != nil {
+//		return err
+//	}
+//	err = client.Block.AppendAll(ctx, pageID, blocks, nil)
+//
+// Paragraphs, headings, bulleted, numbered and task lists, fenced code,
+// quotes, thematic breaks and images on a line of their own are supported.
+// Headings below level 3 become level 3 headings. Tables, HTML blocks and
+// images inside text have no block of their own, their text is kept as a
+// paragraph.
+func ToBlocks(src []byte) ([]notionapi.Block, error) {
+	text := strings.ReplaceAll(string(src), "\r\n", "\n")
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = expandTabs(line, 0)
+	}
+	return parseBlocks(lines), nil
+}
+
+var (
+	atxHeading    = regexp.MustCompile(`^(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	thematicBreak = regexp.MustCompile(`^(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	setextLine    = regexp.MustCompile(`^(?:=+|-+)[ \t]*$`)
+	fenceLine     = regexp.MustCompile("^(`{3,}|~{3,})[ \t]*([^`]*)$")
+	listMarker    = regexp.MustCompile(`^([-+*]|[0-9]{1,9}[.)])([ \t]+|$)`)
+	taskMarker    = regexp.MustCompile(`^\[([ xX])\](?: +|$)`)
+)
+
+// parseBlocks parses lines at the same level of nesting
+func parseBlocks(lines []string) []notionapi.Block {
+	var blocks []notionapi.Block
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if isBlank(line) {
+			i++
+			continue
+		}
+		indent := indentOf(line)
+		trimmed := line[min(indent, 3):]
+
+		switch {
+		case indent < 4 && fenceLine.MatchString(trimmed):
+			block, n := parseCode(lines[i:], indent)
+			blocks = append(blocks, block)
+			i += n
+		case indent < 4 && atxHeading.MatchString(trimmed):
+			m := atxHeading.FindStringSubmatch(trimmed)
+			blocks = append(blocks, heading(len(m[1]), parseInline(m[2])))
+			i++
+		case indent < 4 && thematicBreak.MatchString(trimmed):
+			blocks = append(blocks, &notionapi.DividerBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeDivider})
+			i++
+		case indent < 4 && strings.HasPrefix(trimmed, ">"):
+			block, n := parseQuote(lines[i:])
+			blocks = append(blocks, block)
+			i += n
+		case indent < 4 && listMarker.MatchString(trimmed):
+			block, n := parseListItem(lines[i:])
+			blocks = append(blocks, block)
+			i += n
+		default:
+			block, n := parseParagraph(lines[i:])
+			blocks = append(blocks, block)
+			i += n
+		}
+	}
+	return blocks
+}
+
+// startsBlock reports whether line interrupts a paragraph
+func startsBlock(line string) bool {
+	indent := indentOf(line)
+	if indent >= 4 {
+		return false
+	}
+	line = line[indent:]
+	if m := listMarker.FindStringSubmatch(line); m != nil {
+		// empty items and ordered lists not starting at 1 do not interrupt
+		// a paragraph
+		return m[2] != "" && (len(m[1]) == 1 || m[1][:len(m[1])-1] == "1")
+	}
+	return fenceLine.MatchString(line) || atxHeading.MatchString(line) ||
+		thematicBreak.MatchString(line) || strings.HasPrefix(line, ">")
+}
+
+// parseParagraph parses the lines up to the next blank line or block. An
+// underline of "=" or "-" turns the paragraph into a heading.
+func parseParagraph(lines []string) (notionapi.Block, int) {
+	var text []string
+	n := 0
+	for ; n < len(lines); n++ {
+		line := lines[n]
+		if isBlank(line) || (n > 0 && startsBlock(line) && !setextLine.MatchString(strings.TrimSpace(line))) {
+			break
+		}
+		if n > 0 && indentOf(line) < 4 && setextLine.MatchString(line[indentOf(line):]) {
+			level := 2
+			if strings.HasPrefix(strings.TrimSpace(line), "=") {
+				level = 1
+			}
+			return heading(level, parseInline(strings.Join(text, "\n"))), n + 1
+		}
+		text = append(text, strings.TrimLeft(line, " "))
+	}
+	if b := image(strings.Join(text, "\n")); b != nil {
+		return b, n
+	}
+	return paragraph(parseInline(strings.Join(text, "\n"))), n
+}
+
+// image returns an image block if the paragraph s is a single image, nil
+// otherwise. The alt text becomes the caption.
+func image(s string) notionapi.Block {
+	if !strings.HasPrefix(s, "![") {
+		return nil
+	}
+	alt, dest, end, ok := parseLink(s, 1)
+	if !ok || end != len(s) || dest == "" {
+		return nil
+	}
+	b := &notionapi.ImageBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeImage}
+	b.Image.Type = notionapi.FileTypeExternal
+	b.Image.External = &notionapi.FileObject{URL: dest}
+	if alt != "" {
+		b.Image.Caption = parseInline(alt)
+	}
+	return b
+}
+
+// parseCode parses a fenced code block. Lines are unindented by the indent
+// of the opening fence.
+func parseCode(lines []string, indent int) (notionapi.Block, int) {
+	m := fenceLine.FindStringSubmatch(lines[0][indent:])
+	fence := m[1]
+	var code []string
+	n := 1
+	for ; n < len(lines); n++ {
+		line := lines[n]
+		if indentOf(line) < 4 {
+			closing := strings.TrimSpace(line)
+			if strings.HasPrefix(closing, fence) && strings.Trim(closing, fence[:1]) == "" {
+				n++
+				break
+			}
+		}
+		code = append(code, line[min(indent, indentOf(line)):])
+	}
+
+	b := &notionapi.CodeBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeCode}
+	b.Code.Text = notionapi.Paragraph{rt.Text(strings.Join(code, "\n"))}
+	b.Code.Language = language(strings.Fields(m[2]))
+	return b, n
+}
+
+var languages = map[string]string{
+	"":     "plain text",
+	"text": "plain text",
+	"sh":   "shell",
+	"bash": "shell",
+	"js":   "javascript",
+	"ts":   "typescript",
+	"py":   "python",
+	"rb":   "ruby",
+	"yml":  "yaml",
+	"md":   "markdown",
+}
+
+// language returns the Notion language of the info string of a code fence
+func language(info []string) string {
+	name := ""
+	if len(info) > 0 {
+		name = strings.ToLower(info[0])
+	}
+	if l, ok := languages[name]; ok {
+		return l
+	}
+	return strings.ReplaceAll(name, "-", " ")
+}
+
+// parseQuote parses consecutive lines starting with ">" and the lazy
+// continuation lines of their paragraphs
+func parseQuote(lines []string) (notionapi.Block, int) {
+	var content []string
+	n := 0
+	for ; n < len(lines); n++ {
+		line := lines[n]
+		trimmed := strings.TrimLeft(line, " ")
+		if indentOf(line) < 4 && strings.HasPrefix(trimmed, ">") {
+			trimmed = strings.TrimPrefix(trimmed[1:], " ")
+			content = append(content, trimmed)
+			continue
+		}
+		if isBlank(line) || startsBlock(line) || len(content) == 0 || isBlank(content[len(content)-1]) {
+			break
+		}
+		content = append(content, line)
+	}
+
+	text, children := splitText(parseBlocks(content))
+	b := &notionapi.QuoteBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeQuote}
+	b.Quote.Text = text
+	b.Quote.Children = children
+	return b, n
+}
+
+// parseListItem parses a list item with the lines indented to its content.
+// The first paragraph becomes the text of the item, everything else its
+// children.
+func parseListItem(lines []string) (notionapi.Block, int) {
+	indent := indentOf(lines[0])
+	marker := listMarker.FindStringSubmatch(lines[0][indent:])[1]
+	first := marker + expandTabs(lines[0][indent+len(marker):], indent+len(marker))
+	spaces := indentOf(first[len(marker):])
+	if spaces == 0 || spaces > 4 || isBlank(first[len(marker):]) {
+		spaces = 1
+	}
+	width := indent + len(marker) + spaces
+
+	content := []string{strings.TrimPrefix(first[len(marker):], strings.Repeat(" ", spaces))}
+	n := 1
+	for ; n < len(lines); n++ {
+		line := lines[n]
+		switch {
+		case isBlank(line):
+			content = append(content, "")
+			continue
+		case indentOf(line) >= width:
+			content = append(content, line[width:])
+			continue
+		case isLazy(line) && endsInParagraph(content):
+			content = append(content, line)
+			continue
+		}
+		break
+	}
+	for n > 1 && isBlank(lines[n-1]) {
+		n--
+		content = content[:len(content)-1]
+	}
+
+	checked, task := false, false
+	if len(marker) == 1 {
+		if t := taskMarker.FindStringSubmatch(content[0]); t != nil {
+			checked, task = t[1] != " ", true
+			content[0] = content[0][len(t[0]):]
+		}
+	}
+	text, children := splitText(parseBlocks(content))
+
+	switch {
+	case task:
+		b := &notionapi.ToDoBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeToDo}
+		b.ToDo.Text, b.ToDo.Children, b.ToDo.Checked = text, children, checked
+		return b, n
+	case len(marker) == 1:
+		b := &notionapi.BulletedListItemBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeBulletedListItem}
+		b.BulletedListItem.Text, b.BulletedListItem.Children = text, children
+		return b, n
+	}
+	b := &notionapi.NumberedListItemBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeNumberedListItem}
+	b.NumberedListItem.Text, b.NumberedListItem.Children = text, children
+	return b, n
+}
+
+// isLazy reports whether line may continue the paragraph of a list item
+// without being indented. Any list marker starts the next item.
+func isLazy(line string) bool {
+	trimmed := line[indentOf(line):]
+	return !startsBlock(line) && (indentOf(line) >= 4 || !listMarker.MatchString(trimmed))
+}
+
+// endsInParagraph reports whether the last line of content is paragraph
+// text, which is the only block lazy lines continue
+func endsInParagraph(content []string) bool {
+	last := content[len(content)-1]
+	if isBlank(last) {
+		return false
+	}
+	if trimmed := last[indentOf(last):]; indentOf(last) < 4 && (fenceLine.MatchString(trimmed) ||
+		atxHeading.MatchString(trimmed) || thematicBreak.MatchString(trimmed)) {
+		return false
+	}
+	fence := ""
+	for _, line := range content {
+		if indentOf(line) >= 4 {
+			continue
+		}
+		m := fenceLine.FindStringSubmatch(line[indentOf(line):])
+		switch {
+		case m == nil:
+		case fence == "":
+			fence = m[1]
+		case strings.HasPrefix(m[1], fence) && m[2] == "":
+			fence = ""
+		}
+	}
+	return fence == ""
+}
+
+// splitText returns the text of a leading paragraph and the blocks after it
+func splitText(blocks []notionapi.Block) (notionapi.Paragraph, []notionapi.Block) {
+	if len(blocks) == 0 {
+		return notionapi.Paragraph{}, nil
+	}
+	if p, ok := blocks[0].(*notionapi.ParagraphBlock); ok {
+		return p.Paragraph.Text, blocks[1:]
+	}
+	return notionapi.Paragraph{}, blocks
+}
+
+func paragraph(text notionapi.Paragraph) notionapi.Block {
+	b := &notionapi.ParagraphBlock{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeParagraph}
+	b.Paragraph.Text = text
+	return b
+}
+
+// heading returns a heading block, levels below 3 become level 3
+func heading(level int, text notionapi.Paragraph) notionapi.Block {
+	switch level {
+	case 1:
+		b := &notionapi.Heading1Block{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeHeading1}
+		b.Heading1.Text = text
+		return b
+	case 2:
+		b := &notionapi.Heading2Block{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeHeading2}
+		b.Heading2.Text = text
+		return b
+	}
+	b := &notionapi.Heading3Block{Object: notionapi.ObjectTypeBlock, Type: notionapi.BlockTypeHeading3}
+	b.Heading3.Text = text
+	return b
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// expandTabs replaces the tabs in the indentation of line by spaces up to
+// the next tab stop. col is the column line starts at.
+func expandTabs(line string, col int) string {
+	var b strings.Builder
+	for i, c := range line {
+		switch c {
+		case ' ':
+			b.WriteByte(' ')
+		case '\t':
+			b.WriteString(strings.Repeat(" ", 4-(col+b.Len())%4))
+		default:
+			return b.String() + line[i:]
+		}
+	}
+	return b.String()
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,432 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jomei/notionapi"
+	"github.com/jomei/notionapi/rt"
+)
+
+// node is a span of inline text. Runs of "*", "_" and "~" are nodes with a
+// delimiter until the emphasis is resolved.
+type node struct {
+	text        string
+	annotations notionapi.Annotations
+	link        string
+	equation    bool
+
+	delim             byte
+	count             int
+	canOpen, canClose bool
+}
+
+var (
+	autolink = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\s<>]*)>`)
+	bareURL  = regexp.MustCompile(`^https?://[^\s<]*`)
+	entity   = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+)
+
+// parseInline parses the inline Markdown of a paragraph into rich text
+func parseInline(s string) notionapi.Paragraph {
+	p := notionapi.Paragraph{}
+	for _, n := range inlineNodes(s) {
+		if n.text == "" {
+			continue
+		}
+		if last := len(p) - 1; last >= 0 && !n.equation && p[last].Type == notionapi.ObjectTypeText &&
+			annotations(p[last]) == n.annotations && href(p[last]) == n.link {
+			p[last].Text.Content += n.text
+			continue
+		}
+		p = append(p, richText(n))
+	}
+	return p
+}
+
+func richText(n *node) notionapi.RichText {
+	if n.equation {
+		return rt.Equation(n.text)
+	}
+	r := rt.Text(n.text)
+	if n.annotations != (notionapi.Annotations{}) {
+		r = rt.Styled(n.text, n.annotations)
+	}
+	if n.link != "" {
+		r.Text.Link = &notionapi.Link{URL: n.link}
+	}
+	return r
+}
+
+// inlineNodes splits s into nodes and resolves their emphasis
+func inlineNodes(s string) []*node {
+	var nodes []*node
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, &node{text: text.String()})
+			text.Reset()
+		}
+	}
+	add := func(n ...*node) {
+		flush()
+		nodes = append(nodes, n...)
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			text.WriteByte('\n')
+			i += 2
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			text.WriteByte(s[i+1])
+			i += 2
+		case c == '\n':
+			// two trailing spaces make a hard line break, otherwise the
+			// line break is a space
+			hard := strings.HasSuffix(text.String(), "  ")
+			trimmed := strings.TrimRight(text.String(), " ")
+			text.Reset()
+			text.WriteString(trimmed)
+			if hard {
+				text.WriteByte('\n')
+			} else {
+				text.WriteByte(' ')
+			}
+			i++
+		case c == '`':
+			n := runLength(s, i, '`')
+			end := closingRun(s, i+n, n)
+			if end < 0 {
+				text.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+			add(&node{text: codeContent(s[i+n : end]), annotations: notionapi.Annotations{Code: true}})
+			i = end + n
+		case c == '*' || c == '_' || c == '~':
+			n := runLength(s, i, c)
+			if c == '~' && n > 2 {
+				text.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+			add(delimiter(s, i, n))
+			i += n
+		case c == '[' || (c == '!' && strings.HasPrefix(s[i+1:], "[")):
+			start := i
+			if c == '!' {
+				start++
+			}
+			label, dest, end, ok := parseLink(s, start)
+			if !ok {
+				text.WriteByte(c)
+				i++
+				continue
+			}
+			inner := inlineNodes(label)
+			for _, n := range inner {
+				n.link = dest
+			}
+			add(inner...)
+			i = end
+		case c == '<' && strings.HasPrefix(s[i:], "<u>"):
+			end := strings.Index(s[i+3:], "</u>")
+			if end < 0 {
+				text.WriteByte(c)
+				i++
+				continue
+			}
+			inner := inlineNodes(s[i+3 : i+3+end])
+			for _, n := range inner {
+				n.annotations.Underline = true
+			}
+			add(inner...)
+			i += 3 + end + 4
+		case c == '<' && autolink.MatchString(s[i:]):
+			m := autolink.FindStringSubmatch(s[i:])
+			add(&node{text: m[1], link: m[1]})
+			i += len(m[0])
+		case c == 'h' && bareURL.MatchString(s[i:]) && (i == 0 || strings.ContainsRune(" \n(*_~", rune(s[i-1]))):
+			u := trimURL(bareURL.FindString(s[i:]))
+			add(&node{text: u, link: u})
+			i += len(u)
+		case c == '$':
+			end := closingDollar(s, i)
+			if end < 0 {
+				text.WriteByte(c)
+				i++
+				continue
+			}
+			add(&node{text: s[i+1 : end], equation: true})
+			i = end + 1
+		case c == '&' && entity.MatchString(s[i:]):
+			m := entity.FindString(s[i:])
+			text.WriteString(html.UnescapeString(m))
+			i += len(m)
+		default:
+			text.WriteByte(c)
+			i++
+		}
+	}
+	flush()
+
+	resolveEmphasis(nodes)
+	return nodes
+}
+
+// delimiter returns the node of the run of n delimiters at s[i], which can
+// open or close emphasis depending on the characters around it
+func delimiter(s string, i, n int) *node {
+	prev, next := ' ', ' '
+	if i > 0 {
+		prev, _ = utf8.DecodeLastRuneInString(s[:i])
+	}
+	if i+n < len(s) {
+		next, _ = utf8.DecodeRuneInString(s[i+n:])
+	}
+	left := !unicode.IsSpace(next) && (!isPunct(next) || unicode.IsSpace(prev) || isPunct(prev))
+	right := !unicode.IsSpace(prev) && (!isPunct(prev) || unicode.IsSpace(next) || isPunct(next))
+
+	d := &node{text: s[i : i+n], delim: s[i], count: n, canOpen: left, canClose: right}
+	if d.delim == '_' {
+		d.canOpen = left && (!right || isPunct(prev))
+		d.canClose = right && (!left || isPunct(next))
+	}
+	return d
+}
+
+// resolveEmphasis matches the delimiter runs from the innermost pairs out
+// and annotates the nodes between them. Unmatched delimiters stay text.
+func resolveEmphasis(nodes []*node) {
+	for j := 0; j < len(nodes); {
+		closer := nodes[j]
+		if closer.delim == 0 || !closer.canClose || closer.count == 0 {
+			j++
+			continue
+		}
+		k := j - 1
+		for ; k >= 0; k-- {
+			if opener := nodes[k]; opener.delim == closer.delim && opener.canOpen && opener.count > 0 && matches(opener, closer) {
+				break
+			}
+		}
+		if k < 0 {
+			j++
+			continue
+		}
+
+		opener := nodes[k]
+		use := 1
+		switch {
+		case closer.delim == '~':
+			use = closer.count
+		case opener.count >= 2 && closer.count >= 2:
+			use = 2
+		}
+		for _, n := range nodes[k+1 : j] {
+			switch {
+			case closer.delim == '~':
+				n.annotations.Strikethrough = true
+			case use == 2:
+				n.annotations.Bold = true
+			default:
+				n.annotations.Italic = true
+			}
+			n.canOpen, n.canClose = false, false
+		}
+		opener.count -= use
+		closer.count -= use
+	}
+
+	for _, n := range nodes {
+		if n.delim != 0 {
+			n.text = strings.Repeat(string(n.delim), n.count)
+		}
+	}
+}
+
+// matches applies the rule of three of CommonMark: a run which can both
+// open and close only pairs with one of a length that does not add up to a
+// multiple of three, and strikethrough runs pair with runs of equal length
+func matches(opener, closer *node) bool {
+	if closer.delim == '~' {
+		return opener.count == closer.count
+	}
+	if (opener.canClose || closer.canOpen) && (opener.count+closer.count)%3 == 0 {
+		return opener.count%3 == 0 && closer.count%3 == 0
+	}
+	return true
+}
+
+// parseLink parses an inline link with the label starting at s[i]. It
+// returns the label, the destination and the end of the link.
+func parseLink(s string, i int) (label, dest string, end int, ok bool) {
+	depth := 0
+	j := i + 1
+	for ; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+			continue
+		case '[':
+			depth++
+			continue
+		case ']':
+			depth--
+		default:
+			continue
+		}
+		if depth < 0 {
+			break
+		}
+	}
+	if j+1 >= len(s) || s[j+1] != '(' {
+		return "", "", 0, false
+	}
+	label = s[i+1 : j]
+
+	k := skipSpaces(s, j+2)
+	if k < len(s) && s[k] == '<' {
+		close := strings.IndexAny(s[k:], ">\n")
+		if close < 0 || s[k+close] != '>' {
+			return "", "", 0, false
+		}
+		dest = s[k+1 : k+close]
+		k += close + 1
+	} else {
+		start, parens := k, 0
+		for ; k < len(s) && s[k] > ' '; k++ {
+			if s[k] == '\\' && k+1 < len(s) {
+				k++
+			} else if s[k] == '(' {
+				parens++
+			} else if s[k] == ')' {
+				if parens == 0 {
+					break
+				}
+				parens--
+			}
+		}
+		dest = s[start:k]
+	}
+
+	k = skipSpaces(s, k)
+	if k < len(s) && (s[k] == '"' || s[k] == '\'' || s[k] == '(') {
+		closing := s[k]
+		if closing == '(' {
+			closing = ')'
+		}
+		title := strings.IndexByte(s[k+1:], closing)
+		if title < 0 {
+			return "", "", 0, false
+		}
+		k = skipSpaces(s, k+1+title+1)
+	}
+	if k >= len(s) || s[k] != ')' {
+		return "", "", 0, false
+	}
+	return label, unescape(dest), k + 1, true
+}
+
+// unescape removes backslash escapes and decodes entities
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return html.UnescapeString(b.String())
+}
+
+// trimURL removes trailing punctuation and unbalanced closing parentheses
+// which GitHub does not count as part of bare links
+func trimURL(u string) string {
+	for len(u) > 0 {
+		last := u[len(u)-1]
+		switch {
+		case strings.IndexByte(`?!.,:*_~'"`, last) >= 0:
+			u = u[:len(u)-1]
+		case last == ')' && strings.Count(u, ")") > strings.Count(u, "("):
+			u = u[:len(u)-1]
+		default:
+			return u
+		}
+	}
+	return u
+}
+
+// closingRun returns the start of the next run of exactly n backticks
+// after i, or -1
+func closingRun(s string, i, n int) int {
+	for i < len(s) {
+		j := strings.IndexByte(s[i:], '`')
+		if j < 0 {
+			return -1
+		}
+		i += j
+		run := runLength(s, i, '`')
+		if run == n {
+			return i
+		}
+		i += run
+	}
+	return -1
+}
+
+// codeContent turns line breaks into spaces and strips one space on both
+// sides of a code span
+func codeContent(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) > 2 && s[0] == ' ' && s[len(s)-1] == ' ' && strings.TrimSpace(s) != "" {
+		s = s[1 : len(s)-1]
+	}
+	return s
+}
+
+// closingDollar returns the end of an inline equation starting at s[i], or
+// -1. Like Pandoc it requires the dollars to hug the expression and the
+// closing one not to be followed by a digit, so that prices stay text.
+func closingDollar(s string, i int) int {
+	if i+1 >= len(s) || s[i+1] == ' ' || s[i+1] == '$' {
+		return -1
+	}
+	for j := i + 2; j < len(s); j++ {
+		switch {
+		case s[j] == '\\':
+			j++
+		case s[j] == '$' && s[j-1] != ' ' && (j+1 == len(s) || s[j+1] < '0' || s[j+1] > '9'):
+			return j
+		}
+	}
+	return -1
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func skipSpaces(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+func isASCIIPunct(c byte) bool {
+	return c < utf8.RuneSelf && strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isPunct(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
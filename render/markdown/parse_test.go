@@ -0,0 +1,125 @@
+package markdown_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/jomei/notionapi/render/markdown"
+)
+
+func TestToBlocks(t *testing.T) {
+	src, err := ioutil.ReadFile("testdata/runbook.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks, err := markdown.ToBlocks(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.MarshalIndent(blocks, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	golden := "testdata/runbook.json"
+	if *update {
+		if err := ioutil.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("ToBlocks() differs from %s, run go test -update\n%s", golden, got)
+	}
+}
+
+func TestToBlocks_Render(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "paragraph with soft and hard line breaks",
+			src:  "one\ntwo  \nthree\\\nfour",
+			want: "one two\\\nthree\\\nfour\n",
+		},
+		{
+			name: "nested emphasis",
+			src:  "***both*** **bold *and italic***",
+			want: "***both*** **bold** ***and italic***\n",
+		},
+		{
+			name: "intraword underscores stay text",
+			src:  "snake_case_name and __init__",
+			want: "snake\\_case\\_name and **init**\n",
+		},
+		{
+			name: "unmatched delimiters stay text",
+			src:  "2 * 3 = 6, a ~~b",
+			want: "2 \\* 3 = 6, a \\~\\~b\n",
+		},
+		{
+			name: "code spans keep their content",
+			src:  "run `` a `b` c `` now",
+			want: "run ``a `b` c`` now\n",
+		},
+		{
+			name: "links with nested brackets and parentheses",
+			src:  "[a [b] c](https://example.com/x_(y)) and https://example.com/z.",
+			want: "[a \\[b\\] c](https://example.com/x_%28y%29) and [https://example.com/z](https://example.com/z).\n",
+		},
+		{
+			name: "underline and equations",
+			src:  "<u>under</u> $e = mc^2$ costs $5 and $10",
+			want: "<u>under</u> $e = mc^2$ costs \\$5 and \\$10\n",
+		},
+		{
+			name: "headings below level 3",
+			src:  "#### Four ####\n###### Six",
+			want: "### Four\n\n### Six\n",
+		},
+		{
+			name: "ordered lists not starting at 1 do not interrupt paragraphs",
+			src:  "text\n2. more\n\n1. a\n2. b",
+			want: "text 2. more\n\n1. a\n2. b\n",
+		},
+		{
+			name: "list items with code and paragraphs",
+			src:  "- a\n\n  b\n- ```\n  code\n  ```\nafter",
+			want: "- a\n\n  b\n- ```\n  code\n  ```\n\nafter\n",
+		},
+		{
+			name: "quotes with lazy continuation",
+			src:  "> a\nb\n\nc",
+			want: "> a b\n\nc\n",
+		},
+		{
+			name: "images on their own line",
+			src:  "![the *logo*](https://example.com/logo.png \"Logo\")\n\nsee ![icon](https://example.com/icon.png)",
+			want: "![the logo](https://example.com/logo.png)\n\nsee [icon](https://example.com/icon.png)\n",
+		},
+		{
+			name: "tabs and unclosed fences",
+			src:  "-\tone\n\n\t- two\n\n```go\nfunc f() {}",
+			want: "- one\n  - two\n\n```go\nfunc f() {}\n```\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks, err := markdown.ToBlocks([]byte(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(markdown.Render(blocks, nil)); got != tt.want {
+				t.Errorf("Render(ToBlocks()) got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
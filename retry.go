@@ -0,0 +1,196 @@
+package notionapi
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultRetryMinBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 30 * time.Second
+	// maxRetryAfter is the longest Retry-After delay that is honored, longer
+	// ones are treated as invalid
+	maxRetryAfter = time.Hour
+)
+
+// RetryPolicy configures how failed requests are retried.
+//
+// A request is retried when the API answers with 429 or a 5xx status, or when
+// the transport fails before a response is received. Only idempotent methods
+// (GET, HEAD, OPTIONS, PUT and DELETE) are retried unless more methods are
+// listed in Methods.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the base delay before the first retry. Defaults to 500ms.
+	MinBackoff time.Duration
+	// MaxBackoff caps the computed delay between two attempts. Defaults to
+	// 30s. A Retry-After delay sent by the server is not capped, since
+	// retrying earlier would be rate limited again.
+	MaxBackoff time.Duration
+	// Methods lists additional HTTP methods that are safe to retry,
+	// e.g. http.MethodPost for database queries and search.
+	Methods []string
+}
+
+// WithRetry enables automatic retries of rate limited and failed requests
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		if policy.MinBackoff <= 0 {
+			policy.MinBackoff = defaultRetryMinBackoff
+		}
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = defaultRetryMaxBackoff
+		}
+		if policy.MaxBackoff < policy.MinBackoff {
+			policy.MaxBackoff = policy.MinBackoff
+		}
+		c.retry = &policy
+	}
+}
+
+// RetryError is returned when a request still fails after being retried.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts: %s", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+func (p *RetryPolicy) allowsMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	for _, m := range p.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// retryMiddleware retries failed calls according to p
+func retryMiddleware(p *RetryPolicy) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, call *Call) (interface{}, error) {
+			for attempt := 1; ; attempt++ {
+				v, err := next.Do(ctx, call)
+				if err == nil {
+					return v, nil
+				}
+				if !p.shouldRetry(ctx, call.Method, attempt, err) {
+					if attempt > 1 {
+						return nil, &RetryError{Attempts: attempt, Err: err}
+					}
+					return nil, err
+				}
+
+				var retryAfter time.Duration
+				var apiErr *Error
+				if errors.As(err, &apiErr) {
+					retryAfter = apiErr.retryAfter
+				}
+				if sleepErr := sleepContext(ctx, p.backoff(attempt, retryAfter)); sleepErr != nil {
+					return nil, &RetryError{Attempts: attempt, Err: err}
+				}
+			}
+		})
+	}
+}
+
+// shouldRetry reports whether a call failing with err is retried. API errors
+// are retried by status, transport errors unless the caller gave up.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, err error) bool {
+	if attempt >= p.MaxAttempts || !p.allowsMethod(method) {
+		return false
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return isRetryableStatus(apiErr.Status)
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && ctx.Err() == nil
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+var (
+	jitterMu sync.Mutex
+	jitter   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// backoff returns the delay before the given retry using exponential backoff
+// with equal jitter: half of the delay is fixed and half is random. A
+// Retry-After value sent by the server takes precedence.
+func (p *RetryPolicy) backoff(retry int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	d := p.MaxBackoff
+	if shift := uint(retry - 1); shift < 32 {
+		if exp := p.MinBackoff << shift; exp > 0 && exp < p.MaxBackoff {
+			d = exp
+		}
+	}
+
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return d/2 + time.Duration(jitter.Int63n(int64(d/2)+1))
+}
+
+// parseRetryAfter reads the Retry-After header, which is either a number of
+// seconds or an HTTP date. It returns 0 for missing, malformed, past and
+// absurdly long delays of more than maxRetryAfter.
+func parseRetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	var d time.Duration
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+		if seconds > int64(maxRetryAfter/time.Second) {
+			return 0
+		}
+		d = time.Duration(seconds) * time.Second
+	} else if t, err := http.ParseTime(v); err == nil {
+		d = time.Until(t)
+	}
+	if d <= 0 || d > maxRetryAfter {
+		return 0
+	}
+	return d
+}
+
+// sleepContext waits for d or until ctx is done. It fails right away if ctx
+// would expire before d elapses.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
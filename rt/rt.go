@@ -0,0 +1,106 @@
+// Package rt builds rich text for titles, properties and blocks:
+//
+//	p := notionapi.Paragraph{
+//		rt.Text("Deploy "),
+//		rt.Bold("only"),
+//		rt.Text(" after "),
+//		rt.MentionUser(reviewerID),
+//		rt.Text(" approved, see "),
+//		rt.Link("the runbook", "https://example.com/runbook"),
+//	}
+//
+// Annotated spans set the color to default, so they can be sent as is.
+package rt
+
+import (
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// Text returns plain text without annotations
+func Text(content string) notionapi.RichText {
+	return notionapi.RichText{Type: notionapi.ObjectTypeText, Text: notionapi.Text{Content: content}}
+}
+
+// Styled returns text with the given annotations, an empty color is the
+// default color
+func Styled(content string, annotations notionapi.Annotations) notionapi.RichText {
+	if annotations.Color == "" {
+		annotations.Color = notionapi.ColorDefault
+	}
+	r := Text(content)
+	r.Annotations = &annotations
+	return r
+}
+
+func Bold(content string) notionapi.RichText {
+	return Styled(content, notionapi.Annotations{Bold: true})
+}
+
+func Italic(content string) notionapi.RichText {
+	return Styled(content, notionapi.Annotations{Italic: true})
+}
+
+func Strikethrough(content string) notionapi.RichText {
+	return Styled(content, notionapi.Annotations{Strikethrough: true})
+}
+
+func Underline(content string) notionapi.RichText {
+	return Styled(content, notionapi.Annotations{Underline: true})
+}
+
+// Code returns inline code
+func Code(content string) notionapi.RichText {
+	return Styled(content, notionapi.Annotations{Code: true})
+}
+
+// Color returns text in a color, which may be a background color such as
+// notionapi.ColorRedBackground
+func Color(color notionapi.Color, content string) notionapi.RichText {
+	return Styled(content, notionapi.Annotations{Color: color})
+}
+
+// Link returns text linking to url
+func Link(content, url string) notionapi.RichText {
+	r := Text(content)
+	r.Text.Link = &notionapi.Link{URL: url}
+	return r
+}
+
+func mention(m notionapi.Mention) notionapi.RichText {
+	return notionapi.RichText{Type: notionapi.ObjectTypeMention, Mention: &m}
+}
+
+// MentionUser mentions a user or bot
+func MentionUser(id notionapi.UserID) notionapi.RichText {
+	return mention(notionapi.Mention{
+		Type: notionapi.MentionTypeUser,
+		User: &notionapi.User{Object: notionapi.ObjectTypeUser, ID: id},
+	})
+}
+
+// MentionPage links to a page by its title
+func MentionPage(id notionapi.PageID) notionapi.RichText {
+	return mention(notionapi.Mention{Type: notionapi.MentionTypePage, Page: &notionapi.PageMention{ID: id}})
+}
+
+// MentionDatabase links to a database by its title
+func MentionDatabase(id notionapi.DatabaseID) notionapi.RichText {
+	return mention(notionapi.Mention{Type: notionapi.MentionTypeDatabase, Database: &notionapi.DatabaseMention{ID: id}})
+}
+
+// MentionDate mentions the day of t
+func MentionDate(t time.Time) notionapi.RichText {
+	return mention(notionapi.Mention{Type: notionapi.MentionTypeDate, Date: notionapi.NewDate(t)})
+}
+
+// MentionDateTime mentions the time t
+func MentionDateTime(t time.Time) notionapi.RichText {
+	return mention(notionapi.Mention{Type: notionapi.MentionTypeDate, Date: notionapi.NewDateTime(t)})
+}
+
+// Equation returns an inline KaTeX expression
+func Equation(expression string) notionapi.RichText {
+	return notionapi.RichText{Type: notionapi.ObjectTypeEquation, Equation: &notionapi.Equation{Expression: expression}}
+}
@@ -0,0 +1,42 @@
+package rt_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"github.com/jomei/notionapi/rt"
+)
+
+func TestBuilders(t *testing.T) {
+	tests := []struct {
+		name string
+		text notionapi.RichText
+		want string
+	}{
+		{"text", rt.Text("plain"), `{"type":"text","text":{"content":"plain"}}`},
+		{"bold", rt.Bold("x"), `{"type":"text","text":{"content":"x"},"annotations":{"bold":true,"italic":false,"strikethrough":false,"underline":false,"code":false,"color":"default"}}`},
+		{"code", rt.Code("go test"), `{"type":"text","text":{"content":"go test"},"annotations":{"bold":false,"italic":false,"strikethrough":false,"underline":false,"code":true,"color":"default"}}`},
+		{"color", rt.Color(notionapi.ColorRedBackground, "alert"), `{"type":"text","text":{"content":"alert"},"annotations":{"bold":false,"italic":false,"strikethrough":false,"underline":false,"code":false,"color":"red_background"}}`},
+		{"styled", rt.Styled("both", notionapi.Annotations{Bold: true, Italic: true}), `{"type":"text","text":{"content":"both"},"annotations":{"bold":true,"italic":true,"strikethrough":false,"underline":false,"code":false,"color":"default"}}`},
+		{"link", rt.Link("y", "https://example.com"), `{"type":"text","text":{"content":"y","link":{"url":"https://example.com"}}}`},
+		{"user", rt.MentionUser("some_user_id"), `{"type":"mention","mention":{"type":"user","user":{"object":"user","id":"some_user_id"}}}`},
+		{"page", rt.MentionPage("some_page_id"), `{"type":"mention","mention":{"type":"page","page":{"id":"some_page_id"}}}`},
+		{"database", rt.MentionDatabase("some_database_id"), `{"type":"mention","mention":{"type":"database","database":{"id":"some_database_id"}}}`},
+		{"date", rt.MentionDate(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)), `{"type":"mention","mention":{"type":"date","date":{"start":"2021-06-01"}}}`},
+		{"date time", rt.MentionDateTime(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)), `{"type":"mention","mention":{"type":"date","date":{"start":"2021-06-01T00:00:00Z"}}}`},
+		{"equation", rt.Equation("E=mc^2"), `{"type":"equation","equation":{"expression":"E=mc^2"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.text)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
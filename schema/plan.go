@@ -0,0 +1,324 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// ErrNotConfirmed is returned by Apply when a destructive change was not confirmed
+var ErrNotConfirmed = errors.New("schema: destructive change not confirmed")
+
+type ChangeKind string
+
+const (
+	ChangeAdd     ChangeKind = "add"
+	ChangeRemove  ChangeKind = "remove"
+	ChangeRename  ChangeKind = "rename"
+	ChangeRetype  ChangeKind = "retype"
+	ChangeOptions ChangeKind = "options"
+	ChangeConfig  ChangeKind = "config"
+)
+
+// Change is a single difference between the live and the desired schema
+type Change struct {
+	Kind ChangeKind
+	// Property is the current name of the property, or the new name for additions
+	Property string
+	// Description explains the change in a human readable way
+	Description string
+	// Destructive changes may lose data, e.g. removing or retyping a property
+	Destructive bool
+}
+
+func (c Change) String() string {
+	marks := map[ChangeKind]string{ChangeAdd: "+", ChangeRemove: "-"}
+	mark, ok := marks[c.Kind]
+	if !ok {
+		mark = "~"
+	}
+	s := fmt.Sprintf("%s %s %q: %s", mark, c.Kind, c.Property, c.Description)
+	if c.Destructive {
+		s += " (destructive)"
+	}
+	return s
+}
+
+// Plan lists the changes needed to turn the schema of a database into the
+// desired one.
+type Plan struct {
+	DatabaseID notionapi.DatabaseID
+	Changes    []Change
+
+	updates map[string]*notionapi.PropertyUpdate
+}
+
+// Empty reports whether the database already matches the desired schema
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Destructive returns the changes that may lose data
+func (p *Plan) Destructive() []Change {
+	var result []Change
+	for _, c := range p.Changes {
+		if c.Destructive {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (p *Plan) String() string {
+	if p.Empty() {
+		return fmt.Sprintf("database %s is up to date\n", p.DatabaseID)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "database %s:\n", p.DatabaseID)
+	for _, c := range p.Changes {
+		fmt.Fprintf(&b, "  %s\n", c)
+	}
+	return b.String()
+}
+
+// Request returns the update request applying the plan
+func (p *Plan) Request() *notionapi.DatabaseUpdateRequest {
+	return &notionapi.DatabaseUpdateRequest{Properties: p.updates}
+}
+
+// Diff compares the properties of db with the desired schema. The title
+// property is matched by type, so a new name renames it. Properties of
+// unsupported types are never removed or changed.
+func Diff(db *notionapi.Database, desired *Schema) (*Plan, error) {
+	if err := desired.Validate(); err != nil {
+		return nil, err
+	}
+	live, err := FromDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+	current := map[string]Property{}
+	title := ""
+	for _, p := range live.Properties {
+		current[p.Name] = p
+		if p.Type == notionapi.PropertyTypeTitle {
+			title = p.Name
+		}
+	}
+
+	plan := &Plan{
+		DatabaseID: notionapi.DatabaseID(db.ID),
+		updates:    map[string]*notionapi.PropertyUpdate{},
+	}
+	kept := map[string]bool{}
+	for _, want := range desired.Properties {
+		name := want.Name
+		if want.Type == notionapi.PropertyTypeTitle && title != "" {
+			// Notion does not allow removing the title
+			name = title
+		} else if _, ok := current[name]; !ok && want.RenamedFrom != "" {
+			if _, ok := current[want.RenamedFrom]; ok {
+				name = want.RenamedFrom
+			}
+		}
+		have, ok := current[name]
+		if !ok {
+			plan.add(want)
+			continue
+		}
+		if kept[name] {
+			return nil, fmt.Errorf("schema: two desired properties match %q", name)
+		}
+		kept[name] = true
+		if err := plan.change(name, have, want); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, have := range live.Properties {
+		if !kept[have.Name] && !have.Unsupported() {
+			plan.Changes = append(plan.Changes, Change{
+				Kind:        ChangeRemove,
+				Property:    have.Name,
+				Description: fmt.Sprintf("remove %s property", have.Type),
+				Destructive: true,
+			})
+			plan.updates[have.Name] = nil
+		}
+	}
+	return plan, nil
+}
+
+func (p *Plan) add(want Property) {
+	config, _ := want.config()
+	p.Changes = append(p.Changes, Change{
+		Kind:        ChangeAdd,
+		Property:    want.Name,
+		Description: fmt.Sprintf("add %s property", want.Type),
+	})
+	p.updates[want.Name] = &notionapi.PropertyUpdate{Property: config}
+}
+
+func (p *Plan) change(name string, have, want Property) error {
+	if have.Unsupported() {
+		if want.Name != name || want.Type != have.Type {
+			return fmt.Errorf("schema: can not change %q of unsupported type %s", name, have.Type)
+		}
+		return nil
+	}
+	update := &notionapi.PropertyUpdate{}
+	if want.Name != name {
+		p.Changes = append(p.Changes, Change{
+			Kind:        ChangeRename,
+			Property:    name,
+			Description: fmt.Sprintf("rename to %q", want.Name),
+		})
+		update.Name = want.Name
+	}
+
+	configChanged := true
+	switch {
+	case have.Type != want.Type:
+		if have.Type == notionapi.PropertyTypeTitle || want.Type == notionapi.PropertyTypeTitle {
+			return fmt.Errorf("schema: can not change the type of %q from %s to %s", name, have.Type, want.Type)
+		}
+		p.Changes = append(p.Changes, Change{
+			Kind:        ChangeRetype,
+			Property:    name,
+			Description: fmt.Sprintf("change type from %s to %s", have.Type, want.Type),
+			Destructive: true,
+		})
+	case want.Type == notionapi.PropertyTypeSelect || want.Type == notionapi.PropertyTypeMultiSelect:
+		added, removed, recolored := diffOptions(have.Options, want.Options)
+		if len(added)+len(removed)+len(recolored) == 0 {
+			configChanged = false
+			break
+		}
+		var parts []string
+		if len(added) > 0 {
+			parts = append(parts, "add "+strings.Join(added, ", "))
+		}
+		if len(removed) > 0 {
+			parts = append(parts, "remove "+strings.Join(removed, ", "))
+		}
+		if len(recolored) > 0 {
+			parts = append(parts, "recolor "+strings.Join(recolored, ", "))
+		}
+		p.Changes = append(p.Changes, Change{
+			Kind:        ChangeOptions,
+			Property:    name,
+			Description: strings.Join(parts, "; ") + " options",
+			Destructive: len(removed) > 0,
+		})
+	default:
+		description := diffConfig(have, want)
+		if description == "" {
+			configChanged = false
+			break
+		}
+		p.Changes = append(p.Changes, Change{
+			Kind:        ChangeConfig,
+			Property:    name,
+			Description: description,
+			Destructive: want.Type == notionapi.PropertyTypeRelation,
+		})
+	}
+
+	if configChanged {
+		config, err := want.config()
+		if err != nil {
+			return err
+		}
+		update.Property = config
+	}
+	if update.Name != "" || update.Property != nil {
+		p.updates[name] = update
+	}
+	return nil
+}
+
+// diffOptions compares options by name. Options without a desired color keep
+// their current one.
+func diffOptions(have, want []Option) (added, removed, recolored []string) {
+	current := map[string]Option{}
+	for _, o := range have {
+		current[o.Name] = o
+	}
+	wanted := map[string]bool{}
+	for _, o := range want {
+		wanted[o.Name] = true
+		c, ok := current[o.Name]
+		switch {
+		case !ok:
+			added = append(added, o.Name)
+		case o.Color != "" && o.Color != c.Color:
+			recolored = append(recolored, o.Name)
+		}
+	}
+	for _, o := range have {
+		if !wanted[o.Name] {
+			removed = append(removed, o.Name)
+		}
+	}
+	return added, removed, recolored
+}
+
+func diffConfig(have, want Property) string {
+	switch want.Type {
+	case notionapi.PropertyTypeNumber:
+		if want.Format != "" && want.Format != have.Format {
+			return fmt.Sprintf("change format from %s to %s", have.Format, want.Format)
+		}
+	case notionapi.PropertyTypeFormula:
+		if want.Expression != have.Expression {
+			return fmt.Sprintf("change expression from %q to %q", have.Expression, want.Expression)
+		}
+	case notionapi.PropertyTypeRelation:
+		if want.DatabaseID != have.DatabaseID {
+			return fmt.Sprintf("change related database from %s to %s", have.DatabaseID, want.DatabaseID)
+		}
+	case notionapi.PropertyTypeRollup:
+		if have.Rollup == nil || *want.Rollup != *have.Rollup {
+			return fmt.Sprintf("change rollup to %s of %s.%s", want.Rollup.Function, want.Rollup.RelationProperty, want.Rollup.RollupProperty)
+		}
+	}
+	return ""
+}
+
+// ApplyOptions configures Apply
+type ApplyOptions struct {
+	// Confirm is asked for every destructive change. Without it destructive
+	// changes are rejected.
+	Confirm func(Change) bool
+}
+
+// Apply updates the database schema according to the plan. It fails with
+// ErrNotConfirmed before sending any request if a destructive change is not
+// confirmed.
+func Apply(ctx context.Context, databases notionapi.DatabaseService, plan *Plan, opts *ApplyOptions) (*notionapi.Database, error) {
+	for _, c := range plan.Destructive() {
+		if opts == nil || opts.Confirm == nil || !opts.Confirm(c) {
+			return nil, fmt.Errorf("%w: %s", ErrNotConfirmed, c)
+		}
+	}
+	if plan.Empty() {
+		return databases.Get(ctx, plan.DatabaseID)
+	}
+	return databases.Update(ctx, plan.DatabaseID, plan.Request())
+}
+
+// sortProperties orders properties by name with the title property first
+func sortProperties(properties []Property) {
+	sort.Slice(properties, func(i, j int) bool {
+		ti := properties[i].Type == notionapi.PropertyTypeTitle
+		tj := properties[j].Type == notionapi.PropertyTypeTitle
+		if ti != tj {
+			return ti
+		}
+		return properties[i].Name < properties[j].Name
+	})
+}
@@ -0,0 +1,227 @@
+// Package schema keeps the properties of Notion databases in line with a
+// schema described in Go or YAML.
+//
+// Diff compares the desired schema with a live database and returns a Plan,
+// which can be printed for review and applied with Apply:
+//
+//	desired, err := schema.Parse(yamlFile)
+//	db, err := client.Database.Get(ctx, id)
+//	plan, err := schema.Diff(db, desired)
+//	fmt.Print(plan)
+//	_, err = schema.Apply(ctx, client.Database, plan, &schema.ApplyOptions{Confirm: askUser})
+package schema
+
+import (
+	"fmt"
+
+	"github.com/jomei/notionapi"
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is the desired set of properties of a database
+type Schema struct {
+	Properties []Property `yaml:"properties" json:"properties"`
+}
+
+// Property describes a single database property. Only the fields matching
+// its Type are used.
+type Property struct {
+	Name string                 `yaml:"name" json:"name"`
+	Type notionapi.PropertyType `yaml:"type" json:"type"`
+	// RenamedFrom is the current name of a property that should be renamed to Name
+	RenamedFrom string `yaml:"renamed_from,omitempty" json:"renamed_from,omitempty"`
+
+	// Options of select and multi_select properties
+	Options []Option `yaml:"options,omitempty" json:"options,omitempty"`
+	// Format of number properties
+	Format notionapi.FormatType `yaml:"format,omitempty" json:"format,omitempty"`
+	// Expression of formula properties
+	Expression string `yaml:"expression,omitempty" json:"expression,omitempty"`
+	// DatabaseID of the related database of relation properties
+	DatabaseID notionapi.DatabaseID `yaml:"database_id,omitempty" json:"database_id,omitempty"`
+	// Rollup configures rollup properties
+	Rollup *Rollup `yaml:"rollup,omitempty" json:"rollup,omitempty"`
+
+	// unsupported holds the configuration of a live property whose type this
+	// package does not support, e.g. status. Such properties are kept as
+	// they are.
+	unsupported *notionapi.UnknownPropertyConfig
+}
+
+// Option of a select or multi_select property. An empty Color keeps the
+// color Notion picked.
+type Option struct {
+	Name  string          `yaml:"name" json:"name"`
+	Color notionapi.Color `yaml:"color,omitempty" json:"color,omitempty"`
+}
+
+// Rollup configures a rollup property
+type Rollup struct {
+	RelationProperty string                 `yaml:"relation_property" json:"relation_property"`
+	RollupProperty   string                 `yaml:"rollup_property" json:"rollup_property"`
+	Function         notionapi.FunctionType `yaml:"function" json:"function"`
+}
+
+// Parse reads a schema from YAML. As YAML is a superset of JSON, JSON input
+// works as well.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate checks that property names are unique, types are known and that
+// there is exactly one title property. Properties of unsupported types are
+// only valid in a schema returned by FromDatabase.
+func (s *Schema) Validate() error {
+	names := map[string]bool{}
+	titles := 0
+	for _, p := range s.Properties {
+		if p.Name == "" {
+			return fmt.Errorf("schema: property without name")
+		}
+		if names[p.Name] {
+			return fmt.Errorf("schema: duplicate property %q", p.Name)
+		}
+		names[p.Name] = true
+
+		if p.unsupported == nil {
+			if _, err := p.config(); err != nil {
+				return err
+			}
+		}
+		if p.Type == notionapi.PropertyTypeTitle {
+			titles++
+		}
+	}
+	if titles != 1 {
+		return fmt.Errorf("schema: want exactly one title property, got %d", titles)
+	}
+	return nil
+}
+
+// FromDatabase returns the current schema of db. Properties of types this
+// package does not support are included with their name and type only and
+// are never changed by a plan.
+func FromDatabase(db *notionapi.Database) (*Schema, error) {
+	s := &Schema{}
+	for name, p := range db.Properties {
+		s.Properties = append(s.Properties, propertyFromConfig(name, p))
+	}
+	sortProperties(s.Properties)
+	return s, nil
+}
+
+// Unsupported reports whether p is a live property of a type this package
+// does not support
+func (p Property) Unsupported() bool {
+	return p.unsupported != nil
+}
+
+// config returns the notionapi representation of p used in update requests
+func (p Property) config() (notionapi.PropertyConfig, error) {
+	switch p.Type {
+	case notionapi.PropertyTypeTitle:
+		return notionapi.TitlePropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypeRichText:
+		return notionapi.RichTextPropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypeNumber:
+		format := p.Format
+		if format == "" {
+			format = notionapi.FormatNumber
+		}
+		return notionapi.NumberPropertyConfig{Type: p.Type, Number: notionapi.NumberFormat{Format: format}}, nil
+	case notionapi.PropertyTypeSelect:
+		return notionapi.SelectPropertyConfig{Type: p.Type, Select: notionapi.Select{Options: p.notionOptions()}}, nil
+	case notionapi.PropertyTypeMultiSelect:
+		return notionapi.MultiSelectPropertyConfig{Type: p.Type, MultiSelect: notionapi.Select{Options: p.notionOptions()}}, nil
+	case notionapi.PropertyTypeFormula:
+		if p.Expression == "" {
+			return nil, fmt.Errorf("schema: formula property %q without expression", p.Name)
+		}
+		return notionapi.FormulaPropertyConfig{Type: p.Type, Formula: notionapi.FormulaConfig{Expression: p.Expression}}, nil
+	case notionapi.PropertyTypeRelation:
+		if p.DatabaseID == "" {
+			return nil, fmt.Errorf("schema: relation property %q without database_id", p.Name)
+		}
+		return notionapi.RelationPropertyConfig{Type: p.Type, Relation: notionapi.RelationConfig{DatabaseID: p.DatabaseID}}, nil
+	case notionapi.PropertyTypeRollup:
+		if p.Rollup == nil {
+			return nil, fmt.Errorf("schema: rollup property %q without rollup", p.Name)
+		}
+		return notionapi.RollupPropertyConfig{Type: p.Type, Rollup: notionapi.RollupConfig{
+			RelationPropertyName: p.Rollup.RelationProperty,
+			RollupPropertyName:   p.Rollup.RollupProperty,
+			Function:             p.Rollup.Function,
+		}}, nil
+	case notionapi.PropertyTypeDate:
+		return notionapi.DatePropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypePeople:
+		return notionapi.PeoplePropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypeFiles:
+		return notionapi.FilesPropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypeCheckbox:
+		return notionapi.CheckboxPropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypeURL:
+		return notionapi.URLPropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypeEmail:
+		return notionapi.EmailPropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypePhoneNumber:
+		return notionapi.PhoneNumberPropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypeCreatedTime:
+		return notionapi.CreatedTimePropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypeCreatedBy:
+		return notionapi.CreatedByPropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypeLastEditedTime:
+		return notionapi.LastEditedTimePropertyConfig{Type: p.Type}, nil
+	case notionapi.PropertyTypeLastEditedBy:
+		return notionapi.LastEditedByPropertyConfig{Type: p.Type}, nil
+	}
+	return nil, fmt.Errorf("schema: property %q has unsupported type %q", p.Name, p.Type)
+}
+
+func (p Property) notionOptions() []notionapi.Option {
+	options := make([]notionapi.Option, len(p.Options))
+	for i, o := range p.Options {
+		options[i] = notionapi.Option{Name: o.Name, Color: o.Color}
+	}
+	return options
+}
+
+func propertyFromConfig(name string, p notionapi.PropertyConfig) Property {
+	result := Property{Name: name, Type: p.GetType()}
+	switch p := p.(type) {
+	case *notionapi.NumberPropertyConfig:
+		result.Format = p.Number.Format
+	case *notionapi.SelectPropertyConfig:
+		result.Options = optionsFromNotion(p.Select.Options)
+	case *notionapi.MultiSelectPropertyConfig:
+		result.Options = optionsFromNotion(p.MultiSelect.Options)
+	case *notionapi.FormulaPropertyConfig:
+		result.Expression = p.Formula.Expression
+	case *notionapi.RelationPropertyConfig:
+		result.DatabaseID = p.Relation.DatabaseID
+	case *notionapi.RollupPropertyConfig:
+		result.Rollup = &Rollup{
+			RelationProperty: p.Rollup.RelationPropertyName,
+			RollupProperty:   p.Rollup.RollupPropertyName,
+			Function:         p.Rollup.Function,
+		}
+	case *notionapi.UnknownPropertyConfig:
+		result.unsupported = p
+	}
+	return result
+}
+
+func optionsFromNotion(options []notionapi.Option) []Option {
+	result := make([]Option, len(options))
+	for i, o := range options {
+		result[i] = Option{Name: o.Name, Color: o.Color}
+	}
+	return result
+}
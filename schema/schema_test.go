@@ -0,0 +1,229 @@
+package schema_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jomei/notionapi"
+	"github.com/jomei/notionapi/schema"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+type fakeDatabases struct {
+	notionapi.DatabaseService
+	request *notionapi.DatabaseUpdateRequest
+}
+
+func (f *fakeDatabases) Update(ctx context.Context, id notionapi.DatabaseID, request *notionapi.DatabaseUpdateRequest) (*notionapi.Database, error) {
+	f.request = request
+	return &notionapi.Database{ID: notionapi.ObjectID(id)}, nil
+}
+
+func loadDatabase(t *testing.T) *notionapi.Database {
+	data, err := ioutil.ReadFile("../testdata/database_get.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var db notionapi.Database
+	if err := json.Unmarshal(data, &db); err != nil {
+		t.Fatal(err)
+	}
+	return &db
+}
+
+func loadSchema(t *testing.T) *schema.Schema {
+	data, err := ioutil.ReadFile("testdata/tracker.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := schema.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return s
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "parses json",
+			data: `{"properties": [{"name": "Name", "type": "title"}]}`,
+		},
+		{
+			name:    "requires a title property",
+			data:    `properties: [{name: Price, type: number}]`,
+			wantErr: true,
+		},
+		{
+			name:    "rejects duplicate properties",
+			data:    `properties: [{name: Name, type: title}, {name: Name, type: number}]`,
+			wantErr: true,
+		},
+		{
+			name:    "rejects unknown types",
+			data:    `properties: [{name: Name, type: title}, {name: Price, type: money}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := schema.Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	db := loadDatabase(t)
+
+	t.Run("plans changes", func(t *testing.T) {
+		plan, err := schema.Diff(db, loadSchema(t))
+		if err != nil {
+			t.Fatalf("Diff() error = %v", err)
+		}
+
+		want := `database some_id:
+  ~ options "Tags": add urgent options
+  ~ rename "Some another column": rename to "Owner"
+  + add "Estimate": add number property
+  - remove "SomeColumn": remove rich_text property (destructive)
+`
+		if plan.String() != want {
+			t.Errorf("Diff() got = %s, want %s", plan, want)
+		}
+		if len(plan.Destructive()) != 1 {
+			t.Errorf("Destructive() got = %v, want 1 change", plan.Destructive())
+		}
+	})
+
+	t.Run("is empty for the current schema", func(t *testing.T) {
+		current, err := schema.FromDatabase(db)
+		if err != nil {
+			t.Fatalf("FromDatabase() error = %v", err)
+		}
+		plan, err := schema.Diff(db, current)
+		if err != nil {
+			t.Fatalf("Diff() error = %v", err)
+		}
+		if !plan.Empty() {
+			t.Errorf("Diff() got = %s, want empty plan", plan)
+		}
+	})
+
+	t.Run("renames the title", func(t *testing.T) {
+		current, err := schema.FromDatabase(db)
+		if err != nil {
+			t.Fatalf("FromDatabase() error = %v", err)
+		}
+		current.Properties[0].Name = "Title"
+		plan, err := schema.Diff(db, current)
+		if err != nil {
+			t.Fatalf("Diff() error = %v", err)
+		}
+		want := `database some_id:
+  ~ rename "Name": rename to "Title"
+`
+		if plan.String() != want {
+			t.Errorf("Diff() got = %s, want %s", plan, want)
+		}
+	})
+
+	t.Run("keeps unsupported properties", func(t *testing.T) {
+		db := loadDatabase(t)
+		var status notionapi.PropertyConfigs
+		if err := json.Unmarshal([]byte(`{"Status": {"id": "s", "type": "status", "status": {"options": []}}}`), &status); err != nil {
+			t.Fatal(err)
+		}
+		db.Properties["Status"] = status["Status"]
+
+		current, err := schema.FromDatabase(db)
+		if err != nil {
+			t.Fatalf("FromDatabase() error = %v", err)
+		}
+		found := false
+		for _, p := range current.Properties {
+			found = found || (p.Name == "Status" && p.Unsupported())
+		}
+		if !found {
+			t.Errorf("FromDatabase() got = %v, want unsupported Status property", current.Properties)
+		}
+		if plan, err := schema.Diff(db, current); err != nil || !plan.Empty() {
+			t.Errorf("Diff() got = %v, %v, want empty plan", plan, err)
+		}
+
+		plan, err := schema.Diff(db, loadSchema(t))
+		if err != nil {
+			t.Fatalf("Diff() error = %v", err)
+		}
+		for _, c := range plan.Changes {
+			if c.Property == "Status" {
+				t.Errorf("Diff() planned %s", c)
+			}
+		}
+	})
+
+	t.Run("rejects retyping the title", func(t *testing.T) {
+		desired := &schema.Schema{Properties: []schema.Property{
+			{Name: "Name", Type: notionapi.PropertyTypeRichText},
+			{Name: "Title", Type: notionapi.PropertyTypeTitle},
+		}}
+		if _, err := schema.Diff(db, desired); err == nil {
+			t.Errorf("Diff() error = nil, want error")
+		}
+	})
+}
+
+func TestApply(t *testing.T) {
+	db := loadDatabase(t)
+	plan, err := schema.Diff(db, loadSchema(t))
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	t.Run("requires confirmation of destructive changes", func(t *testing.T) {
+		databases := &fakeDatabases{}
+		_, err := schema.Apply(context.Background(), databases, plan, &schema.ApplyOptions{
+			Confirm: func(schema.Change) bool { return false },
+		})
+		if !errors.Is(err, schema.ErrNotConfirmed) {
+			t.Errorf("Apply() error = %v, want %v", err, schema.ErrNotConfirmed)
+		}
+		if databases.request != nil {
+			t.Errorf("Apply() sent request %v", databases.request)
+		}
+	})
+
+	t.Run("updates the database", func(t *testing.T) {
+		databases := &fakeDatabases{}
+		var confirmed []string
+		_, err := schema.Apply(context.Background(), databases, plan, &schema.ApplyOptions{
+			Confirm: func(c schema.Change) bool {
+				confirmed = append(confirmed, c.Property)
+				return true
+			},
+		})
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if !reflect.DeepEqual(confirmed, []string{"SomeColumn"}) {
+			t.Errorf("Apply() confirmed = %v", confirmed)
+		}
+
+		got, err := json.Marshal(databases.request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"properties":{"Estimate":{"number":{"format":"number"},"type":"number"},"Some another column":{"name":"Owner"},"SomeColumn":null,"Tags":{"multi_select":{"options":[{"name":"tag"},{"name":"urgent","color":"red"}]},"type":"multi_select"}}}`
+		if string(got) != want {
+			t.Errorf("Apply() request got = %s, want %s", got, want)
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 type SearchService interface {
 	Do(context.Context, *SearchRequest) (*SearchResponse, error)
+	DoAll(context.Context, *SearchRequest) *ObjectIterator
 }
 
 type SearchClient struct {
@@ -17,18 +18,42 @@ type SearchClient struct {
 
 // Do search https://developers.notion.com/reference/post-search
 func (sc *SearchClient) Do(ctx context.Context, request *SearchRequest) (*SearchResponse, error) {
-	res, err := sc.apiClient.request(ctx, http.MethodPost, "search", nil, request)
+	call := &Call{
+		Operation: "search.do",
+		Method:    http.MethodPost,
+		Path:      "search",
+		Body:      request,
+		decode:    decodeJSON(&SearchResponse{}),
+	}
+	res, err := sc.apiClient.call(ctx, call)
 	if err != nil {
 		return nil, err
 	}
 
-	var response SearchResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
+	response, ok := res.(*SearchResponse)
+	if !ok {
+		return nil, unexpectedResponse(call, res)
 	}
+	return response, nil
+}
 
-	return &response, nil
+// DoAll iterates over all search results, starting at request.StartCursor if set
+func (sc *SearchClient) DoAll(ctx context.Context, request *SearchRequest) *ObjectIterator {
+	var req SearchRequest
+	if request != nil {
+		req = *request
+	}
+	it := &ObjectIterator{}
+	it.iterator = newIterator(ctx, req.StartCursor, func(ctx context.Context, cursor Cursor) (int, Cursor, bool, error) {
+		req.StartCursor = cursor
+		res, err := sc.Do(ctx, &req)
+		if err != nil {
+			return 0, "", false, err
+		}
+		it.items = res.Results
+		return len(res.Results), res.NextCursor, res.HasMore, nil
+	})
+	return it
 }
 
 type SearchRequest struct {
@@ -0,0 +1,120 @@
+package notionapi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithStrictDecoding makes requests fail when a response contains a block,
+// property or rich text type this library does not support, instead of
+// returning it as UnknownBlock, UnknownProperty, UnknownPropertyConfig or
+// RichText with Raw JSON.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		c.strict = true
+	}
+}
+
+// checkSupported returns an error if v contains an UnknownBlock,
+// UnknownProperty or UnknownPropertyConfig
+func checkSupported(v interface{}) error {
+	switch v := v.(type) {
+	case *UnknownBlock:
+		return fmt.Errorf("unsupported block type: %s", v.Type)
+	case *UnknownProperty:
+		return fmt.Errorf("unsupported property type: %s", v.Type)
+	case *UnknownPropertyConfig:
+		return fmt.Errorf("unsupported property type: %s", v.Type)
+	case Properties:
+		for _, p := range v {
+			if err := checkSupported(p); err != nil {
+				return err
+			}
+		}
+	case PropertyConfigs:
+		for _, p := range v {
+			if err := checkSupported(p); err != nil {
+				return err
+			}
+		}
+	case *Page:
+		return checkSupported(v.Properties)
+	case *Database:
+		return checkSupported(v.Properties)
+	case *GetChildrenResponse:
+		for _, b := range v.Results {
+			if err := checkSupported(b); err != nil {
+				return err
+			}
+		}
+	case *DatabaseQueryResponse:
+		for i := range v.Results {
+			if err := checkSupported(&v.Results[i]); err != nil {
+				return err
+			}
+		}
+	case *DatabaseListResponse:
+		for i := range v.Results {
+			if err := checkSupported(&v.Results[i]); err != nil {
+				return err
+			}
+		}
+	case *SearchResponse:
+		for _, o := range v.Results {
+			if err := checkSupported(o); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var richTextType = reflect.TypeOf(RichText{})
+
+// checkRichText returns an error if v contains rich text of a type this
+// library does not support
+func checkRichText(v interface{}) error {
+	return checkRichTextValue(reflect.ValueOf(v))
+}
+
+func checkRichTextValue(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return checkRichTextValue(v.Elem())
+	case reflect.Struct:
+		if v.Type() == richTextType {
+			if r := v.Interface().(RichText); !r.supported() {
+				return fmt.Errorf("unsupported rich text type: %s", r.Type)
+			}
+			return nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if err := checkRichTextValue(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := checkRichTextValue(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := checkRichTextValue(iter.Value()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
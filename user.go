@@ -2,7 +2,6 @@ package notionapi
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -16,6 +15,7 @@ func (uID UserID) String() string {
 type UserService interface {
 	Get(context.Context, UserID) (*User, error)
 	List(context.Context, *Pagination) (*UsersListResponse, error)
+	ListAll(context.Context, *Pagination) *UserIterator
 }
 
 type UserClient struct {
@@ -24,34 +24,58 @@ type UserClient struct {
 
 // Get https://developers.notion.com/reference/get-user
 func (uc *UserClient) Get(ctx context.Context, id UserID) (*User, error) {
-	res, err := uc.apiClient.request(ctx, http.MethodGet, fmt.Sprintf("users/%s", id.String()), nil, nil)
-	if err != nil {
-		return nil, err
+	call := &Call{
+		Operation: "users.get",
+		ObjectID:  id.String(),
+		Method:    http.MethodGet,
+		Path:      fmt.Sprintf("users/%s", id.String()),
+		decode:    decodeJSON(&User{}),
 	}
-
-	var response User
-	err = json.NewDecoder(res.Body).Decode(&response)
+	res, err := uc.apiClient.call(ctx, call)
 	if err != nil {
 		return nil, err
 	}
 
-	return &response, nil
+	response, ok := res.(*User)
+	if !ok {
+		return nil, unexpectedResponse(call, res)
+	}
+	return response, nil
 }
 
 // List https://developers.notion.com/reference/get-users
 func (uc *UserClient) List(ctx context.Context, pagination *Pagination) (*UsersListResponse, error) {
-	res, err := uc.apiClient.request(ctx, http.MethodGet, "users", pagination.ToQuery(), nil)
+	call := &Call{
+		Operation: "users.list",
+		Method:    http.MethodGet,
+		Path:      "users",
+		Query:     pagination.ToQuery(),
+		decode:    decodeJSON(&UsersListResponse{}),
+	}
+	res, err := uc.apiClient.call(ctx, call)
 	if err != nil {
 		return nil, err
 	}
 
-	var response UsersListResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
+	response, ok := res.(*UsersListResponse)
+	if !ok {
+		return nil, unexpectedResponse(call, res)
 	}
+	return response, nil
+}
 
-	return &response, nil
+// ListAll iterates over all users, starting at pagination.StartCursor if set
+func (uc *UserClient) ListAll(ctx context.Context, pagination *Pagination) *UserIterator {
+	it := &UserIterator{}
+	it.iterator = newIterator(ctx, startCursor(pagination), func(ctx context.Context, cursor Cursor) (int, Cursor, bool, error) {
+		res, err := uc.List(ctx, paginationFrom(pagination, cursor))
+		if err != nil {
+			return 0, "", false, err
+		}
+		it.items = res.Results
+		return len(res.Results), res.NextCursor, res.HasMore, nil
+	})
+	return it
 }
 
 type UserType string
@@ -59,11 +83,11 @@ type UserType string
 type User struct {
 	Object    ObjectType `json:"object"`
 	ID        UserID     `json:"id"`
-	Type      UserType   `json:"type"`
-	Name      string     `json:"name"`
-	AvatarURL string     `json:"avatar_url"`
-	Person    *Person    `json:"person"`
-	Bot       *Bot       `json:"bot"`
+	Type      UserType   `json:"type,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	AvatarURL string     `json:"avatar_url,omitempty"`
+	Person    *Person    `json:"person,omitempty"`
+	Bot       *Bot       `json:"bot,omitempty"`
 }
 
 type Person struct {
@@ -0,0 +1,333 @@
+package notionapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxRichTextObjects is the maximum length of a rich text array
+const maxRichTextObjects = 100
+
+var blocksType = reflect.TypeOf([]Block(nil))
+
+// WithValidation checks the requests to create and update pages, query
+// databases, search and append blocks before they are sent. Invalid requests
+// fail with a *ValidationError instead of a validation_error of the API.
+// The check is a middleware running inside those added by WithMiddleware.
+func WithValidation() ClientOption {
+	return func(c *Client) {
+		c.validate = true
+	}
+}
+
+// ValidationError lists the problems found in a request by WithValidation
+type ValidationError struct {
+	// Operation is the operation of the request, e.g. "pages.create"
+	Operation string
+	Fields    []FieldError
+}
+
+// FieldError is a problem with a single field of a request
+type FieldError struct {
+	// Path is the JSON path of the field, e.g. "children[2].paragraph.text[0].text.content"
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		problems[i] = f.Path + ": " + f.Message
+	}
+	return fmt.Sprintf("invalid %s request: %s", e.Operation, strings.Join(problems, "; "))
+}
+
+// validationMiddleware fails calls with invalid requests without passing
+// them on
+func validationMiddleware(next Doer) Doer {
+	return DoerFunc(func(ctx context.Context, call *Call) (interface{}, error) {
+		if err := validateCall(call); err != nil {
+			return nil, err
+		}
+		return next.Do(ctx, call)
+	})
+}
+
+// validateCall checks the body of call and returns a *ValidationError if it
+// has any problems
+func validateCall(call *Call) error {
+	v := &validator{}
+	switch r := call.Body.(type) {
+	case *PageCreateRequest:
+		if r == nil {
+			break
+		}
+		v.parent("parent", r.Parent)
+		v.properties("properties", r.Properties)
+		v.children("children", r.Children)
+	case *PageUpdateRequest:
+		if r == nil {
+			break
+		}
+		v.properties("properties", r.Properties)
+	case *DatabaseQueryRequest:
+		if r == nil {
+			break
+		}
+		v.pageSize("page_size", r.PageSize)
+		v.sorts("sorts", r.Sorts)
+		switch {
+		case r.Filter != nil:
+			v.filter("filter", r.Filter)
+		case r.PropertyFilter != nil:
+			v.filter("filter", r.PropertyFilter)
+		case r.CompoundFilter != nil:
+			v.filter("filter", r.CompoundFilter)
+		}
+	case *SearchRequest:
+		if r == nil {
+			break
+		}
+		v.pageSize("page_size", r.PageSize)
+		if r.Sort != nil && r.Sort.Timestamp != TimestampLastEdited {
+			v.addf("sort.timestamp", "search can only be sorted by %s", TimestampLastEdited)
+		}
+		if r.Filter != nil {
+			v.searchFilter("filter", r.Filter)
+		}
+	case *AppendBlockChildrenRequest:
+		if r == nil {
+			break
+		}
+		v.children("children", r.Children)
+	}
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Operation: call.Operation, Fields: v.fields}
+}
+
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) addf(path, format string, args ...interface{}) {
+	v.fields = append(v.fields, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) parent(path string, p Parent) {
+	switch {
+	case p.PageID == "" && p.DatabaseID == "":
+		v.addf(path, "page_id or database_id is required")
+	case p.PageID != "" && p.DatabaseID != "":
+		v.addf(path, "only one of page_id and database_id can be set")
+	case p.Type == ParentTypePageID && p.PageID == "":
+		v.addf(path+".page_id", "is required by type %s", p.Type)
+	case p.Type == ParentTypeDatabaseID && p.DatabaseID == "":
+		v.addf(path+".database_id", "is required by type %s", p.Type)
+	}
+}
+
+func (v *validator) pageSize(path string, size int) {
+	// 0 leaves the page size to the API
+	if size < 0 || size > 100 {
+		v.addf(path, "must be between 1 and 100, got %d", size)
+	}
+}
+
+func (v *validator) sorts(path string, sorts []SortObject) {
+	for i, s := range sorts {
+		if (s.Property == "") == (s.Timestamp == "") {
+			v.addf(fmt.Sprintf("%s[%d]", path, i), "needs exactly one of property and timestamp")
+		}
+	}
+}
+
+// filter checks the shape of a database filter. Property filters are checked
+// by their Validate method.
+func (v *validator) filter(path string, f Filter) {
+	switch f := f.(type) {
+	case AndCompoundFilter:
+		v.compound(path+"."+string(FilterOperatorAND), f)
+	case OrCompoundFilter:
+		v.compound(path+"."+string(FilterOperatorOR), f)
+	case CompoundFilter:
+		if len(f) != 1 {
+			v.addf(path, "compound filter needs exactly one operator, got %d", len(f))
+		}
+		for operator, filters := range f {
+			p := path + "." + string(operator)
+			if operator != FilterOperatorAND && operator != FilterOperatorOR {
+				v.addf(p, "unknown filter operator")
+				continue
+			}
+			if len(filters) == 0 {
+				v.addf(p, "empty %s filter", operator)
+			}
+			for i := range filters {
+				v.filter(fmt.Sprintf("%s[%d]", p, i), &filters[i])
+			}
+		}
+	case *PropertyFilter:
+		if f == nil {
+			v.addf(path, "missing filter")
+		} else if err := f.Validate(); err != nil {
+			v.addf(path, "%s", err)
+		}
+	default:
+		if err := f.Validate(); err != nil {
+			v.addf(path, "%s", err)
+		}
+	}
+}
+
+func (v *validator) compound(path string, filters []Filter) {
+	if len(filters) == 0 {
+		v.addf(path, "empty filter")
+	}
+	for i, f := range filters {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		if f == nil {
+			v.addf(p, "missing filter")
+			continue
+		}
+		v.filter(p, f)
+	}
+}
+
+// searchFilter checks that the filter of a search limits the object type
+func (v *validator) searchFilter(path string, f interface{}) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		v.addf(path, "%s", err)
+		return
+	}
+	var filter struct {
+		Property string `json:"property"`
+		Value    string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &filter); err != nil {
+		v.addf(path, "must be an object with property and value")
+		return
+	}
+	if filter.Property != "object" {
+		v.addf(path+".property", "must be object, got %q", filter.Property)
+	}
+	if filter.Value != string(ObjectTypePage) && filter.Value != string(ObjectTypeDatabase) {
+		v.addf(path+".value", "must be page or database, got %q", filter.Value)
+	}
+}
+
+// properties checks the rich text of title and rich text properties
+func (v *validator) properties(path string, props Properties) {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := path + "." + name
+		switch prop := indirectProperty(props[name]).(type) {
+		case TitleProperty:
+			v.richText(p+".title", prop.Title)
+		case RichTextProperty:
+			v.richText(p+".rich_text", prop.RichText)
+		}
+	}
+}
+
+func (v *validator) richText(path string, p Paragraph) {
+	if len(p) > maxRichTextObjects {
+		v.addf(path, "at most %d rich text objects are allowed, got %d", maxRichTextObjects, len(p))
+	}
+	for i, rt := range p {
+		if n := utf8.RuneCountInString(rt.Text.Content); n > maxTextLength {
+			v.addf(fmt.Sprintf("%s[%d].text.content", path, i), "at most %d characters are allowed, got %d", maxTextLength, n)
+		}
+	}
+}
+
+// children checks the blocks of a request against the limits of
+// AppendChildren
+func (v *validator) children(path string, blocks []Block) {
+	if n := countBlocks(blocks); n > maxAppendBlocks {
+		v.addf(path, "at most %d blocks are allowed in one request, got %d", maxAppendBlocks, n)
+	}
+	v.blocks(path, blocks, 1)
+}
+
+func countBlocks(blocks []Block) int {
+	n := len(blocks)
+	for _, b := range blocks {
+		if isNilBlock(b) {
+			continue
+		}
+		if _, _, children := BlockChildren(b); children != nil {
+			n += countBlocks(*children)
+		}
+	}
+	return n
+}
+
+func isNilBlock(b Block) bool {
+	if b == nil {
+		return true
+	}
+	rv := reflect.ValueOf(b)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// blocks checks a children array at depth, the top level being 1
+func (v *validator) blocks(path string, blocks []Block, depth int) {
+	if len(blocks) == 0 {
+		return
+	}
+	if depth > maxAppendDepth {
+		v.addf(path, "blocks can be nested at most %d levels deep in one request", maxAppendDepth)
+		return
+	}
+	if len(blocks) > maxAppendChildren {
+		v.addf(path, "at most %d children are allowed, got %d", maxAppendChildren, len(blocks))
+	}
+	for i, b := range blocks {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		if isNilBlock(b) {
+			v.addf(p, "missing block")
+			continue
+		}
+		rv := reflect.Indirect(reflect.ValueOf(b))
+		if rv.Kind() == reflect.Struct {
+			v.blockFields(p, rv, depth)
+		}
+	}
+}
+
+// blockFields checks the rich text and children in the fields of the struct
+// rv
+func (v *validator) blockFields(path string, rv reflect.Value, depth int) {
+	t := rv.Type()
+	for i := 0; i < rv.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		f := rv.Field(i)
+		switch {
+		case f.Type() == paragraphType:
+			v.richText(path+"."+name, f.Interface().(Paragraph))
+		case f.Type() == blocksType:
+			v.blocks(path+"."+name, f.Interface().([]Block), depth+1)
+		case f.Kind() == reflect.Struct && f.Type() != timeType:
+			v.blockFields(path+"."+name, f, depth)
+		}
+	}
+}